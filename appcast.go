@@ -1,19 +1,25 @@
 // Package appcast provides functionality for working with appcasts to retrieve
 // valuable information about software releases.
 //
-// Currently supports 3 providers: "GitHub Atom Feed", "SourceForge RSS Feed"
-// and "Sparkle RSS Feed". However, it can be extended to your own needs
-// if necessary.
+// Currently supports 6 providers: "AppStream Metainfo", "GitHub Atom Feed",
+// "JSON Feed", "NuGet Feed", "SourceForge RSS Feed" and "Sparkle RSS Feed". Other RSS 2.0 and Atom
+// feeds are handled by the "Generic RSS/Atom Feed" fallback provider. However,
+// it can be extended to your own needs if necessary.
 //
 // See README.md for more info.
 package appcast
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/victorpopkov/go-appcast/appcaster"
 	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/provider/appstream"
+	"github.com/victorpopkov/go-appcast/provider/generic"
 	"github.com/victorpopkov/go-appcast/provider/github"
+	"github.com/victorpopkov/go-appcast/provider/jsonfeed"
+	"github.com/victorpopkov/go-appcast/provider/nuget"
 	"github.com/victorpopkov/go-appcast/provider/sourceforge"
 	"github.com/victorpopkov/go-appcast/provider/sparkle"
 	"github.com/victorpopkov/go-appcast/source"
@@ -24,11 +30,16 @@ type Appcaster interface {
 	appcaster.Appcaster
 	LoadFromRemoteSource(i interface{}) (appcaster.Appcaster, []error)
 	LoadFromLocalSource(path string) (appcaster.Appcaster, []error)
+	Candidates() provider.Candidates
 }
 
 // Appcast represents the appcast itself.
 type Appcast struct {
 	appcaster.Appcast
+
+	// candidates specifies the provider candidates ranked by their confidence
+	// as guessed by the Appcast.GuessSourceProvider.
+	candidates provider.Candidates
 }
 
 // New returns a new Appcast instance pointer. The source can be passed as a
@@ -103,21 +114,29 @@ func (a *Appcast) LoadSource() error {
 }
 
 // GuessSourceProvider attempts to guess the supported provider based on the
-// Appcast.source.content.
+// Appcast.source.content. For the remote sources, the URL is checked first.
+//
+// The ranked provider candidates with their confidence and reasons are kept in
+// the Appcast.candidates, so the misdetections can be debugged.
 func (a *Appcast) GuessSourceProvider() {
 	switch src := a.Source().(type) {
 	case *source.Remote:
-		src.SetProvider(provider.GuessProviderByUrl(src.Url()))
-		if src.Provider() == provider.Unknown {
-			src.SetProvider(provider.GuessProviderByContent(src.Content()))
-		}
-	case *source.Local:
-		src.SetProvider(provider.GuessProviderByContent(src.Content()))
+		a.candidates = provider.GuessProvidersByUrl(src.Url()).Merge(provider.GuessProvidersByContent(src.Content()))
+		src.SetProvider(a.candidates.Best())
+	case *source.Local, *source.Reader, *source.Memory, *source.FS:
+		a.candidates = provider.GuessProvidersByContent(src.Content())
+		src.SetProvider(a.candidates.Best())
 	default:
+		a.candidates = nil
 		src.SetProvider(provider.Unknown)
 	}
 }
 
+// Candidates is an Appcast.candidates getter.
+func (a *Appcast) Candidates() provider.Candidates {
+	return a.candidates
+}
+
 // Unmarshal unmarshals the Appcast.source.content into the Appcast.releases by
 // calling the appropriate provider-specific Unmarshal method from the supported
 // providers.
@@ -140,6 +159,18 @@ func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
 	case provider.GitHub:
 		appcast = &github.Appcast{Appcast: a.Appcast}
 		break
+	case provider.NuGet:
+		appcast = &nuget.Appcast{Appcast: a.Appcast}
+		break
+	case provider.JSONFeed:
+		appcast = &jsonfeed.Appcast{Appcast: a.Appcast}
+		break
+	case provider.Generic:
+		appcast = &generic.Appcast{Appcast: a.Appcast}
+		break
+	case provider.AppStream:
+		appcast = &appstream.Appcast{Appcast: a.Appcast}
+		break
 	default:
 		name := p.String()
 		if name == "-" {
@@ -159,24 +190,68 @@ func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
 	return appcast, errors
 }
 
-// Uncomment uncomments the commented out lines by calling the appropriate
-// provider-specific Uncomment method from the supported providers.
+// Uncomment uncomments the commented out feed items by calling the appropriate
+// provider-specific Uncomment method from the supported providers. The
+// releases of these items are marked as commented on unmarshalling.
 func (a *Appcast) Uncomment() error {
 	if a.Source() == nil {
 		return fmt.Errorf("no source")
 	}
 
+	var appcast interface {
+		appcaster.Appcaster
+		CommentedItems() map[int]bool
+	}
+
 	p := a.Source().Provider()
 
 	switch p {
 	case provider.Sparkle:
-		appcast := sparkle.Appcast{Appcast: a.Appcast}
+		appcast = &sparkle.Appcast{Appcast: a.Appcast}
+	case provider.SourceForge:
+		appcast = &sourceforge.Appcast{Appcast: a.Appcast}
+	case provider.GitHub:
+		appcast = &github.Appcast{Appcast: a.Appcast}
+	case provider.NuGet:
+		appcast = &nuget.Appcast{Appcast: a.Appcast}
+	case provider.Generic:
+		appcast = &generic.Appcast{Appcast: a.Appcast}
+	case provider.AppStream:
+		appcast = &appstream.Appcast{Appcast: a.Appcast}
+	default:
+		name := p.String()
+		if name == "-" {
+			name = "Unknown"
+		}
+
+		return fmt.Errorf("uncommenting is not available for the \"%s\" provider", name)
+	}
+
+	if err := appcast.Uncomment(); err != nil {
+		return err
+	}
 
-		_ = appcast.Uncomment()
+	a.Source().SetContent(appcast.Source().Content())
+	a.SetCommentedItems(appcast.CommentedItems())
 
-		a.Source().SetContent(appcast.Appcast.Source().Content())
+	return nil
+}
 
-		return nil
+// NewDecoder returns a new provider-specific decoder reading the releases one
+// by one from the provided reader without loading the whole appcast. Only the
+// "GitHub Atom Feed", "NuGet Feed", "SourceForge RSS Feed" and "Sparkle RSS
+// Feed" providers are supported. The "NuGet Feed" is supported only as the
+// OData Atom feed.
+func NewDecoder(r io.Reader, p provider.Provider) (appcaster.ReleaseDecoder, error) {
+	switch p {
+	case provider.Sparkle:
+		return sparkle.NewDecoder(r), nil
+	case provider.SourceForge:
+		return sourceforge.NewDecoder(r), nil
+	case provider.GitHub:
+		return github.NewDecoder(r), nil
+	case provider.NuGet:
+		return nuget.NewDecoder(r), nil
 	}
 
 	name := p.String()
@@ -184,5 +259,5 @@ func (a *Appcast) Uncomment() error {
 		name = "Unknown"
 	}
 
-	return fmt.Errorf("uncommenting is not available for the \"%s\" provider", name)
+	return nil, fmt.Errorf("releases for the \"%s\" provider can't be decoded", name)
 }
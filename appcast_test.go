@@ -10,16 +10,21 @@ import (
 	"path/filepath"
 	"regexp"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
-	"gopkg.in/jarcoal/httpmock.v1"
 
 	"github.com/victorpopkov/go-appcast/appcaster"
 	"github.com/victorpopkov/go-appcast/client"
 	"github.com/victorpopkov/go-appcast/output"
 	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/provider/appstream"
+	"github.com/victorpopkov/go-appcast/provider/generic"
 	"github.com/victorpopkov/go-appcast/provider/github"
+	"github.com/victorpopkov/go-appcast/provider/jsonfeed"
+	"github.com/victorpopkov/go-appcast/provider/nuget"
 	"github.com/victorpopkov/go-appcast/provider/sourceforge"
 	"github.com/victorpopkov/go-appcast/provider/sparkle"
 	"github.com/victorpopkov/go-appcast/release"
@@ -271,7 +276,7 @@ func TestAppcast_LoadFromRemoteSource(t *testing.T) {
 	err := errors[0]
 
 	assert.Error(t, err)
-	assert.EqualError(t, err, fmt.Sprintf("parse %s: invalid URL escape \"%%31\"", url))
+	assert.EqualError(t, err, fmt.Sprintf("parse %q: invalid URL escape \"%%31\"", url))
 	assert.IsType(t, &Appcast{}, a)
 	assert.Nil(t, p)
 	assert.Nil(t, a.Source())
@@ -284,7 +289,7 @@ func TestAppcast_LoadFromRemoteSource(t *testing.T) {
 	err = errors[0]
 
 	assert.Error(t, err)
-	assert.EqualError(t, err, "Get invalid: no responder found")
+	assert.EqualError(t, err, "Get \"invalid\": no responder found")
 	assert.IsType(t, &Appcast{}, a)
 	assert.Nil(t, p)
 	assert.Nil(t, a.Source())
@@ -398,6 +403,11 @@ func TestAppcast_GuessSourceProvider(t *testing.T) {
 		"https://example.com/appcast.xml",
 		httpmock.NewBytesResponder(200, getTestdata(path)),
 	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://github.com/user/repo/releases.atom",
+		httpmock.NewBytesResponder(200, getTestdata("../provider/github/testdata/unmarshal/default.xml")),
+	)
 	defer httpmock.DeactivateAndReset()
 
 	// test (*source.Remote)
@@ -410,6 +420,24 @@ func TestAppcast_GuessSourceProvider(t *testing.T) {
 	a := New(remote)
 	a.GuessSourceProvider()
 	assert.Equal(t, provider.Sparkle, a.Source().Provider())
+	assert.Len(t, a.Candidates(), 2)
+	assert.Equal(t, provider.Sparkle, a.Candidates()[0].Provider)
+	assert.Equal(t, provider.Generic, a.Candidates()[1].Provider)
+
+	// test (*source.Remote) [URL]
+	remote, err = source.NewRemote("https://github.com/user/repo/releases.atom")
+	assert.Nil(t, err)
+
+	err = remote.Load()
+	assert.Nil(t, err)
+
+	a = New(remote)
+	a.GuessSourceProvider()
+	assert.Equal(t, provider.GitHub, a.Source().Provider())
+	assert.Equal(t, provider.GitHub, a.Candidates()[0].Provider)
+	assert.Equal(t, 1.0, a.Candidates()[0].Confidence)
+	assert.Equal(t, "the URL matches the provider URL", a.Candidates()[0].Reasons[0])
+	assert.Equal(t, "the feed ID is a GitHub tag URI", a.Candidates()[0].Reasons[1])
 
 	// test (*source.Local)
 	local := source.NewLocal(getTestdataPath(path))
@@ -420,6 +448,39 @@ func TestAppcast_GuessSourceProvider(t *testing.T) {
 	a = New(local)
 	a.GuessSourceProvider()
 	assert.Equal(t, provider.Sparkle, a.Source().Provider())
+	assert.Equal(t, provider.Sparkle, a.Candidates().Best())
+
+	// test (*source.Reader)
+	reader := source.NewReader(bytes.NewReader(getTestdata(path)))
+
+	err = reader.Load()
+	assert.Nil(t, err)
+
+	a = New(reader)
+	a.GuessSourceProvider()
+	assert.Equal(t, provider.Sparkle, a.Source().Provider())
+
+	// test (*source.Memory)
+	memory, err := source.NewMemory(getTestdata(path))
+	assert.Nil(t, err)
+
+	err = memory.Load()
+	assert.Nil(t, err)
+
+	a = New(memory)
+	a.GuessSourceProvider()
+	assert.Equal(t, provider.Sparkle, a.Source().Provider())
+
+	// test (*source.FS)
+	fsys := fstest.MapFS{"appcast.xml": &fstest.MapFile{Data: getTestdata(path)}}
+	fs := source.NewFS(fsys, "appcast.xml")
+
+	err = fs.Load()
+	assert.Nil(t, err)
+
+	a = New(fs)
+	a.GuessSourceProvider()
+	assert.Equal(t, provider.Sparkle, a.Source().Provider())
 
 	// test (default)
 	src := new(appcaster.Source)
@@ -428,16 +489,46 @@ func TestAppcast_GuessSourceProvider(t *testing.T) {
 	a = New(src)
 	a.GuessSourceProvider()
 	assert.Equal(t, provider.Unknown, a.Source().Provider())
+	assert.Nil(t, a.Candidates())
+}
+
+func TestAppcast_Candidates(t *testing.T) {
+	a := New()
+	assert.Equal(t, a.candidates, a.Candidates())
 }
 
 func TestAppcast_Unmarshal(t *testing.T) {
 	testCases := map[string]map[string]interface{}{
+		"../provider/appstream/testdata/unmarshal/default.xml": {
+			"provider": provider.AppStream,
+			"appcast":  &appstream.Appcast{},
+			"checksum": "4205134edf7e837658a67ad9bca3f335d6f8157990bd37636f6b945b970af9a0",
+			"releases": 4,
+		},
+		"../provider/generic/testdata/unmarshal/atom.xml": {
+			"provider": provider.Generic,
+			"appcast":  &generic.Appcast{},
+			"checksum": "4b00b1eb88cbb641cbf925bef9433c74552e8f198c74ac8bb0824e8cc874a6de",
+			"releases": 4,
+		},
 		"../provider/github/testdata/unmarshal/default.xml": {
 			"provider": provider.GitHub,
 			"appcast":  &github.Appcast{},
 			"checksum": "c28ff87daf2c02471fd2c836b7ed3776d927a8febbb6b8961daf64ce332f6185",
 			"releases": 4,
 		},
+		"../provider/jsonfeed/testdata/unmarshal/default.json": {
+			"provider": provider.JSONFeed,
+			"appcast":  &jsonfeed.Appcast{},
+			"checksum": "4c97f63e50d949cebbe77daae3a3b512df7745fbd2db38fc283929a018f74a71",
+			"releases": 4,
+		},
+		"../provider/nuget/testdata/unmarshal/default.xml": {
+			"provider": provider.NuGet,
+			"appcast":  &nuget.Appcast{},
+			"checksum": "937d92fec0f2ba677de71a8ecf01016181a75a65bc3b7a1b0d2145d3ca1af72b",
+			"releases": 4,
+		},
 		"../provider/sourceforge/testdata/unmarshal/default.xml": {
 			"provider": provider.SourceForge,
 			"appcast":  &sourceforge.Appcast{},
@@ -522,23 +613,39 @@ func TestAppcast_Unmarshal(t *testing.T) {
 
 func TestAppcast_Uncomment(t *testing.T) {
 	testCases := map[string]map[string]interface{}{
-		"../provider/github/testdata/unmarshal/default.xml": {
-			"error": "uncommenting is not available for the \"GitHub Atom Feed\" provider",
+		"../provider/appstream/testdata/unmarshal/default.xml": {
+			"commented": map[int]bool{},
 		},
-		"../provider/sourceforge/testdata/unmarshal/default.xml": {
-			"error": "uncommenting is not available for the \"SourceForge RSS Feed\" provider",
+		"../provider/generic/testdata/unmarshal/rss.xml": {
+			"commented": map[int]bool{},
+		},
+		"../provider/github/testdata/unmarshal/with_comments.xml": {
+			"lines":     []int{9, 19},
+			"commented": map[int]bool{0: true},
+		},
+		"../provider/jsonfeed/testdata/unmarshal/default.json": {
+			"error": "uncommenting is not available for the \"JSON Feed\" provider",
+		},
+		"../provider/nuget/testdata/unmarshal/default.xml": {
+			"commented": map[int]bool{},
+		},
+		"../provider/sourceforge/testdata/unmarshal/with_comments.xml": {
+			"lines":     []int{23, 34},
+			"commented": map[int]bool{1: true},
+		},
+		"../provider/sparkle/testdata/unmarshal/commented_items.xml": {
+			"lines":     []int{8, 14, 15, 21},
+			"commented": map[int]bool{0: true, 1: true},
 		},
 		"../provider/sparkle/testdata/unmarshal/with_comments.xml": {
-			"lines": []int{13, 20},
+			"commented": map[int]bool{},
 		},
 		"unknown.xml": {
 			"error": "uncommenting is not available for the \"Unknown\" provider",
 		},
 	}
 
-	regexCommentStart := regexp.MustCompile(`<!--([[:space:]]*)?<`)
-	regexCommentEnd := regexp.MustCompile(`>([[:space:]]*)?-->`)
-
+	regexComment := regexp.MustCompile(`<!--|-->`)
 	// test
 	for path, data := range testCases {
 		// preparations
@@ -551,11 +658,14 @@ func TestAppcast_Uncomment(t *testing.T) {
 			// test (successful)
 			assert.Nil(t, err)
 
-			for _, commentLine := range data["lines"].([]int) {
-				line, _ := getLine(commentLine, a.Source().Content())
-				check := regexCommentStart.MatchString(line) && regexCommentEnd.MatchString(line)
-				assert.False(t, check)
+			if lines, ok := data["lines"].([]int); ok {
+				for _, commentLine := range lines {
+					line, _ := getLine(commentLine, a.Source().Content())
+					assert.False(t, regexComment.MatchString(line))
+				}
 			}
+
+			assert.Equal(t, data["commented"], a.CommentedItems())
 		} else {
 			// test (error)
 			assert.Error(t, err)
@@ -571,3 +681,47 @@ func TestAppcast_Uncomment(t *testing.T) {
 	assert.EqualError(t, err, "no source")
 	assert.Nil(t, a.Source())
 }
+
+func TestNewDecoder(t *testing.T) {
+	testCases := map[string]map[string]interface{}{
+		"../provider/github/testdata/unmarshal/default.xml": {
+			"provider": provider.GitHub,
+			"decoder":  &github.Decoder{},
+		},
+		"../provider/sourceforge/testdata/unmarshal/default.xml": {
+			"provider": provider.SourceForge,
+			"decoder":  &sourceforge.Decoder{},
+		},
+		"../provider/sparkle/testdata/unmarshal/default.xml": {
+			"provider": provider.Sparkle,
+			"decoder":  &sparkle.Decoder{},
+		},
+		"../provider/nuget/testdata/unmarshal/default.xml": {
+			"provider": provider.NuGet,
+			"decoder":  &nuget.Decoder{},
+		},
+		"unknown.xml": {
+			"provider": provider.Unknown,
+			"error":    "releases for the \"Unknown\" provider can't be decoded",
+		},
+	}
+
+	// test
+	for path, data := range testCases {
+		d, err := NewDecoder(bytes.NewReader(getTestdata(path)), data["provider"].(provider.Provider))
+
+		if data["error"] == nil {
+			// test (successful)
+			assert.Nil(t, err)
+			assert.IsType(t, data["decoder"], d)
+
+			r, err := d.Next()
+			assert.Nil(t, err, path)
+			assert.NotNil(t, r, path)
+		} else {
+			// test (error)
+			assert.Nil(t, d)
+			assert.EqualError(t, err, data["error"].(string))
+		}
+	}
+}
@@ -7,6 +7,7 @@ package appcaster
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 
 	"github.com/victorpopkov/go-appcast/release"
@@ -53,6 +54,16 @@ type Appcast struct {
 
 	// releases specify an appcast releases.
 	releases release.Releaseser
+
+	// lenient specifies whether the malformed XML content should be repaired
+	// while unmarshalling instead of failing the whole appcast. The repairs are
+	// returned alongside the release errors as Repair warnings.
+	lenient bool
+
+	// commentedItems specifies the 0-based indexes of the feed items that have
+	// been uncommented by the Appcast.Uncomment. The releases created from
+	// these items are marked as commented.
+	commentedItems map[int]bool
 }
 
 // New returns a new Appcast instance pointer. The source can be passed as a
@@ -94,13 +105,15 @@ func (a *Appcast) GenerateSourceChecksum(algorithm ChecksumAlgorithm) *Checksum
 
 // LoadSource sets the Appcast.source.content field value depending on the
 // source type. It should call the appropriate Appcast.Source.Load methods
-// chain.
+// chain. The Appcast.commentedItems are reset, as the content is replaced.
 func (a *Appcast) LoadSource() error {
 	err := a.Source().Load()
 	if err != nil {
 		return err
 	}
 
+	a.commentedItems = nil
+
 	return nil
 }
 
@@ -126,6 +139,38 @@ func (a *Appcast) Uncomment() error {
 	panic("implement me")
 }
 
+// UncommentItems uncomments the commented out feed items with the provided
+// local names in the Appcast.source.content using the UncommentItems function
+// and keeps their indexes in the Appcast.commentedItems. It's designed to be
+// called by the provider-specific Uncomment methods.
+func (a *Appcast) UncommentItems(names ...string) error {
+	if a.Source() == nil || len(a.Source().Content()) == 0 {
+		return fmt.Errorf("no source")
+	}
+
+	content, indexes := UncommentItems(a.Source().Content(), names...)
+	a.Source().SetContent(content)
+
+	a.commentedItems = make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		a.commentedItems[i] = true
+	}
+
+	return nil
+}
+
+// UnmarshalContent parses the provided XML content into the provided pointer
+// value using either UnmarshalXML or UnmarshalXMLLenient depending on the
+// Appcast.lenient. Returns the parsed content, which should be used for
+// locating the elements, and the repairs made in the lenient mode.
+func (a *Appcast) UnmarshalContent(content []byte, v interface{}) ([]byte, Errors, error) {
+	if a.lenient {
+		return UnmarshalXMLLenient(content, v)
+	}
+
+	return content, nil, UnmarshalXML(content, v)
+}
+
 // Source is an Appcast.source getter.
 func (a *Appcast) Source() Sourcer {
 	return a.source
@@ -156,6 +201,26 @@ func (a *Appcast) SetReleases(releases release.Releaseser) {
 	a.releases = releases
 }
 
+// CommentedItems is an Appcast.commentedItems getter.
+func (a *Appcast) CommentedItems() map[int]bool {
+	return a.commentedItems
+}
+
+// SetCommentedItems is an Appcast.commentedItems setter.
+func (a *Appcast) SetCommentedItems(commentedItems map[int]bool) {
+	a.commentedItems = commentedItems
+}
+
+// Lenient is an Appcast.lenient getter.
+func (a *Appcast) Lenient() bool {
+	return a.lenient
+}
+
+// SetLenient is an Appcast.lenient setter.
+func (a *Appcast) SetLenient(lenient bool) {
+	a.lenient = lenient
+}
+
 // FirstRelease is a convenience method to get the first filtered release from
 // the Appcast.releases.
 func (a *Appcast) FirstRelease() release.Releaser {
@@ -240,6 +240,24 @@ func TestAppcast_Uncomment(t *testing.T) {
 	})
 }
 
+func TestAppcast_UncommentItems(t *testing.T) {
+	// preparations
+	a := newTestAppcast([]byte("<rss><channel><!-- <item><title>2.0.0</title></item> --><item><title>1.0.0</title></item></channel></rss>"))
+
+	// test
+	err := a.UncommentItems("item")
+	assert.Nil(t, err)
+	assert.Equal(t, "<rss><channel> <item><title>2.0.0</title></item> <item><title>1.0.0</title></item></channel></rss>", string(a.Source().Content()))
+	assert.Equal(t, map[int]bool{0: true}, a.commentedItems)
+
+	// test (error) [no source]
+	a = new(Appcast)
+
+	err = a.UncommentItems("item")
+	assert.EqualError(t, err, "no source")
+	assert.Nil(t, a.commentedItems)
+}
+
 func TestAppcast_Source(t *testing.T) {
 	a := newTestAppcast()
 	assert.Equal(t, a.source, a.Source())
@@ -285,6 +303,51 @@ func TestAppcast_SetReleases(t *testing.T) {
 	assert.Nil(t, a.releases)
 }
 
+func TestAppcast_UnmarshalContent(t *testing.T) {
+	// preparations
+	a := newTestAppcast()
+	content := []byte("<rss><channel><item><title>A & B</title></item></channel></rss>")
+
+	// test
+	var feed testLenientFeed
+	result, repairs, err := a.UnmarshalContent(content, &feed)
+	assert.Error(t, err)
+	assert.Equal(t, content, result)
+	assert.Nil(t, repairs)
+
+	// test (lenient)
+	a.SetLenient(true)
+
+	feed = testLenientFeed{}
+	result, repairs, err = a.UnmarshalContent(content, &feed)
+	assert.Nil(t, err)
+	assert.Equal(t, "<rss><channel><item><title>A &amp; B</title></item></channel></rss>", string(result))
+	assert.Len(t, repairs, 1)
+	assert.Equal(t, "A & B", feed.Items[0].Title)
+}
+
+func TestAppcast_Lenient(t *testing.T) {
+	a := newTestAppcast()
+	assert.False(t, a.Lenient())
+}
+
+func TestAppcast_SetLenient(t *testing.T) {
+	a := newTestAppcast()
+	a.SetLenient(true)
+	assert.True(t, a.lenient)
+}
+
+func TestAppcast_CommentedItems(t *testing.T) {
+	a := newTestAppcast()
+	assert.Equal(t, a.commentedItems, a.CommentedItems())
+}
+
+func TestAppcast_SetCommentedItems(t *testing.T) {
+	a := newTestAppcast()
+	a.SetCommentedItems(map[int]bool{1: true})
+	assert.Equal(t, map[int]bool{1: true}, a.commentedItems)
+}
+
 func TestAppcast_FirstRelease(t *testing.T) {
 	a := newTestAppcast()
 	assert.Equal(t, a.releases.First(), a.FirstRelease())
@@ -0,0 +1,290 @@
+package appcaster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UTF8 is the canonical name of the UTF-8 charset.
+const UTF8 = "utf-8"
+
+// charsetAliases holds the supported charset labels and their canonical names.
+//
+// The "iso-8859-1" and "us-ascii" labels are decoded as "windows-1252" just
+// like the browsers do, since the legacy feeds declaring them usually contain
+// the Windows "smart quotes" anyway.
+var charsetAliases = map[string]string{
+	"utf-8":        UTF8,
+	"utf8":         UTF8,
+	"us-ascii":     "windows-1252",
+	"ascii":        "windows-1252",
+	"iso-8859-1":   "windows-1252",
+	"iso8859-1":    "windows-1252",
+	"iso_8859-1":   "windows-1252",
+	"latin1":       "windows-1252",
+	"l1":           "windows-1252",
+	"windows-1252": "windows-1252",
+	"cp1252":       "windows-1252",
+	"windows-1251": "windows-1251",
+	"cp1251":       "windows-1251",
+	"koi8-r":       "koi8-r",
+	"koi8r":        "koi8-r",
+	"utf-16":       "utf-16",
+	"utf-16le":     "utf-16le",
+	"utf-16be":     "utf-16be",
+}
+
+// charmap represents the upper half of a single-byte charset. The lower half
+// is always ASCII.
+type charmap [128]rune
+
+// charmaps holds the supported single-byte charsets by their canonical names.
+var charmaps = map[string]*charmap{
+	"windows-1251": {
+		0x0402, 0x0403, 0x201A, 0x0453, 0x201E, 0x2026, 0x2020, 0x2021,
+		0x20AC, 0x2030, 0x0409, 0x2039, 0x040A, 0x040C, 0x040B, 0x040F,
+		0x0452, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+		0x0098, 0x2122, 0x0459, 0x203A, 0x045A, 0x045C, 0x045B, 0x045F,
+		0x00A0, 0x040E, 0x045E, 0x0408, 0x00A4, 0x0490, 0x00A6, 0x00A7,
+		0x0401, 0x00A9, 0x0404, 0x00AB, 0x00AC, 0x00AD, 0x00AE, 0x0407,
+		0x00B0, 0x00B1, 0x0406, 0x0456, 0x0491, 0x00B5, 0x00B6, 0x00B7,
+		0x0451, 0x2116, 0x0454, 0x00BB, 0x0458, 0x0405, 0x0455, 0x0457,
+		0x0410, 0x0411, 0x0412, 0x0413, 0x0414, 0x0415, 0x0416, 0x0417,
+		0x0418, 0x0419, 0x041A, 0x041B, 0x041C, 0x041D, 0x041E, 0x041F,
+		0x0420, 0x0421, 0x0422, 0x0423, 0x0424, 0x0425, 0x0426, 0x0427,
+		0x0428, 0x0429, 0x042A, 0x042B, 0x042C, 0x042D, 0x042E, 0x042F,
+		0x0430, 0x0431, 0x0432, 0x0433, 0x0434, 0x0435, 0x0436, 0x0437,
+		0x0438, 0x0439, 0x043A, 0x043B, 0x043C, 0x043D, 0x043E, 0x043F,
+		0x0440, 0x0441, 0x0442, 0x0443, 0x0444, 0x0445, 0x0446, 0x0447,
+		0x0448, 0x0449, 0x044A, 0x044B, 0x044C, 0x044D, 0x044E, 0x044F,
+	},
+	"windows-1252": {
+		0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+		0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+		0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+		0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+		0x00A0, 0x00A1, 0x00A2, 0x00A3, 0x00A4, 0x00A5, 0x00A6, 0x00A7,
+		0x00A8, 0x00A9, 0x00AA, 0x00AB, 0x00AC, 0x00AD, 0x00AE, 0x00AF,
+		0x00B0, 0x00B1, 0x00B2, 0x00B3, 0x00B4, 0x00B5, 0x00B6, 0x00B7,
+		0x00B8, 0x00B9, 0x00BA, 0x00BB, 0x00BC, 0x00BD, 0x00BE, 0x00BF,
+		0x00C0, 0x00C1, 0x00C2, 0x00C3, 0x00C4, 0x00C5, 0x00C6, 0x00C7,
+		0x00C8, 0x00C9, 0x00CA, 0x00CB, 0x00CC, 0x00CD, 0x00CE, 0x00CF,
+		0x00D0, 0x00D1, 0x00D2, 0x00D3, 0x00D4, 0x00D5, 0x00D6, 0x00D7,
+		0x00D8, 0x00D9, 0x00DA, 0x00DB, 0x00DC, 0x00DD, 0x00DE, 0x00DF,
+		0x00E0, 0x00E1, 0x00E2, 0x00E3, 0x00E4, 0x00E5, 0x00E6, 0x00E7,
+		0x00E8, 0x00E9, 0x00EA, 0x00EB, 0x00EC, 0x00ED, 0x00EE, 0x00EF,
+		0x00F0, 0x00F1, 0x00F2, 0x00F3, 0x00F4, 0x00F5, 0x00F6, 0x00F7,
+		0x00F8, 0x00F9, 0x00FA, 0x00FB, 0x00FC, 0x00FD, 0x00FE, 0x00FF,
+	},
+	"koi8-r": {
+		0x2500, 0x2502, 0x250C, 0x2510, 0x2514, 0x2518, 0x251C, 0x2524,
+		0x252C, 0x2534, 0x253C, 0x2580, 0x2584, 0x2588, 0x258C, 0x2590,
+		0x2591, 0x2592, 0x2593, 0x2320, 0x25A0, 0x2219, 0x221A, 0x2248,
+		0x2264, 0x2265, 0x00A0, 0x2321, 0x00B0, 0x00B2, 0x00B7, 0x00F7,
+		0x2550, 0x2551, 0x2552, 0x0451, 0x2553, 0x2554, 0x2555, 0x2556,
+		0x2557, 0x2558, 0x2559, 0x255A, 0x255B, 0x255C, 0x255D, 0x255E,
+		0x255F, 0x2560, 0x2561, 0x0401, 0x2562, 0x2563, 0x2564, 0x2565,
+		0x2566, 0x2567, 0x2568, 0x2569, 0x256A, 0x256B, 0x256C, 0x00A9,
+		0x044E, 0x0430, 0x0431, 0x0446, 0x0434, 0x0435, 0x0444, 0x0433,
+		0x0445, 0x0438, 0x0439, 0x043A, 0x043B, 0x043C, 0x043D, 0x043E,
+		0x043F, 0x044F, 0x0440, 0x0441, 0x0442, 0x0443, 0x0436, 0x0432,
+		0x044C, 0x044B, 0x0437, 0x0448, 0x044D, 0x0449, 0x0447, 0x044A,
+		0x042E, 0x0410, 0x0411, 0x0426, 0x0414, 0x0415, 0x0424, 0x0413,
+		0x0425, 0x0418, 0x0419, 0x041A, 0x041B, 0x041C, 0x041D, 0x041E,
+		0x041F, 0x042F, 0x0420, 0x0421, 0x0422, 0x0423, 0x0416, 0x0412,
+		0x042C, 0x042B, 0x0417, 0x0428, 0x042D, 0x0429, 0x0427, 0x042A,
+	},
+}
+
+// decode appends the UTF-8 representation of the provided single-byte content
+// to dst and returns the extended slice.
+func (c *charmap) decode(dst []byte, src []byte) []byte {
+	var buf [utf8.UTFMax]byte
+
+	for _, b := range src {
+		if b < utf8.RuneSelf {
+			dst = append(dst, b)
+			continue
+		}
+
+		n := utf8.EncodeRune(buf[:], c[b-utf8.RuneSelf])
+		dst = append(dst, buf[:n]...)
+	}
+
+	return dst
+}
+
+// Byte order marks.
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// xmlEncodingRegexp matches the encoding of the XML declaration.
+var xmlEncodingRegexp = regexp.MustCompile(`^\s*<\?xml[^>]*?\sencoding\s*=\s*["']([A-Za-z0-9._:-]+)["']`)
+
+// NormalizeCharset returns the canonical name of the provided charset label.
+// Returns an error, if the charset isn't supported.
+func NormalizeCharset(label string) (string, error) {
+	charset, ok := charsetAliases[strings.ToLower(strings.TrimSpace(label))]
+	if !ok {
+		return "", fmt.Errorf("unsupported charset: %s", label)
+	}
+
+	return charset, nil
+}
+
+// DetectCharset returns the charset label of the provided content. The
+// "Content-Type" header value can be provided for the remote content.
+//
+// The charset is detected in the following order: BOM, XML declaration and
+// "Content-Type". The XML declaration is preferred over the header, as the
+// servers often send their default charset regardless of the file. For the
+// same reason, the header charset is used only when it's supported and the
+// content isn't a valid UTF-8. The unsupported declared charsets are skipped
+// as well. Falls back to the UTF-8.
+func DetectCharset(content []byte, contentType string) string {
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		return UTF8
+	case bytes.HasPrefix(content, bomUTF16LE), bytes.HasPrefix(content, []byte("<\x00?\x00")):
+		return "utf-16le"
+	case bytes.HasPrefix(content, bomUTF16BE), bytes.HasPrefix(content, []byte("\x00<\x00?")):
+		return "utf-16be"
+	}
+
+	if m := xmlEncodingRegexp.FindSubmatch(content); m != nil {
+		if _, err := NormalizeCharset(string(m[1])); err == nil {
+			return string(m[1])
+		}
+	}
+
+	if contentType != "" && !utf8.Valid(content) {
+		_, params, err := mime.ParseMediaType(contentType)
+		if err == nil && params["charset"] != "" {
+			if _, err := NormalizeCharset(params["charset"]); err == nil {
+				return params["charset"]
+			}
+		}
+	}
+
+	return UTF8
+}
+
+// ToUTF8 transcodes the provided content from the provided charset into UTF-8.
+// The BOM is removed and the XML declaration encoding is replaced with the
+// "UTF-8", so the result can be unmarshalled without any charset handling.
+// Returns an error, if the charset isn't supported.
+func ToUTF8(content []byte, label string) ([]byte, error) {
+	charset, err := NormalizeCharset(label)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+
+	switch charset {
+	case UTF8:
+		return bytes.TrimPrefix(content, bomUTF8), nil
+	case "utf-16", "utf-16le", "utf-16be":
+		result = decodeUTF16(content, charset == "utf-16be")
+	default:
+		result = charmaps[charset].decode(make([]byte, 0, len(content)), content)
+	}
+
+	if m := xmlEncodingRegexp.FindSubmatchIndex(result); m != nil {
+		declaration := append(result[:m[2]:m[2]], "UTF-8"...)
+		result = append(declaration, result[m[3]:]...)
+	}
+
+	return result, nil
+}
+
+// decodeUTF16 decodes the provided UTF-16 content into UTF-8. The BOM
+// overrides the provided byte order.
+func decodeUTF16(content []byte, bigEndian bool) []byte {
+	switch {
+	case bytes.HasPrefix(content, bomUTF16LE):
+		content, bigEndian = content[2:], false
+	case bytes.HasPrefix(content, bomUTF16BE):
+		content, bigEndian = content[2:], true
+	}
+
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(content[2*i])<<8 | uint16(content[2*i+1])
+		} else {
+			units[i] = uint16(content[2*i+1])<<8 | uint16(content[2*i])
+		}
+	}
+
+	return []byte(string(utf16.Decode(units)))
+}
+
+// CharsetReader returns a reader transcoding the provided input from the
+// provided single-byte charset into UTF-8. It's designed to be used as the
+// xml.Decoder.CharsetReader, so the legacy feeds can be streamed as well.
+func CharsetReader(label string, input io.Reader) (io.Reader, error) {
+	charset, err := NormalizeCharset(label)
+	if err != nil {
+		return nil, err
+	}
+
+	if charset == UTF8 {
+		return input, nil
+	}
+
+	c, ok := charmaps[charset]
+	if !ok {
+		return nil, fmt.Errorf("unsupported charset: %s", label)
+	}
+
+	return &charsetReader{r: input, charmap: c}, nil
+}
+
+// charsetReader transcodes the single-byte charset reader into UTF-8 on the
+// fly.
+type charsetReader struct {
+	r       io.Reader
+	charmap *charmap
+	in      [4096]byte
+	out     []byte
+	err     error
+}
+
+// Read reads the transcoded UTF-8 content.
+func (c *charsetReader) Read(p []byte) (int, error) {
+	for len(c.out) == 0 {
+		if c.err != nil {
+			return 0, c.err
+		}
+
+		var n int
+		n, c.err = c.r.Read(c.in[:])
+		c.out = c.charmap.decode(c.out[:0], c.in[:n])
+	}
+
+	n := copy(p, c.out)
+	c.out = c.out[n:]
+
+	return n, nil
+}
+
+// UnmarshalXML parses the provided XML content into the provided value just
+// like xml.Unmarshal does, but also supports the legacy single-byte charsets
+// declared in the XML declaration.
+func UnmarshalXML(content []byte, v interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.CharsetReader = CharsetReader
+
+	return decoder.Decode(v)
+}
@@ -0,0 +1,128 @@
+package appcaster
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLegacyItem represents a single legacy test feed item.
+type testLegacyItem struct {
+	Title string `xml:"channel>item>title"`
+}
+
+func TestNormalizeCharset(t *testing.T) {
+	testCases := map[string]string{
+		"UTF-8":        "utf-8",
+		" utf8 ":       "utf-8",
+		"ISO-8859-1":   "windows-1252",
+		"us-ascii":     "windows-1252",
+		"Windows-1251": "windows-1251",
+		"KOI8-R":       "koi8-r",
+		"UTF-16":       "utf-16",
+	}
+
+	for label, expected := range testCases {
+		charset, err := NormalizeCharset(label)
+		assert.Nil(t, err, label)
+		assert.Equal(t, expected, charset, label)
+	}
+
+	// test (error)
+	charset, err := NormalizeCharset("shift_jis")
+	assert.Empty(t, charset)
+	assert.EqualError(t, err, "unsupported charset: shift_jis")
+}
+
+func TestDetectCharset(t *testing.T) {
+	testCases := []struct {
+		content     string
+		contentType string
+		expected    string
+	}{
+		{"\xEF\xBB\xBF<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>", "", "utf-8"},
+		{"\xFF\xFE<\x00?\x00", "", "utf-16le"},
+		{"<\x00?\x00x\x00", "", "utf-16le"},
+		{"\xFE\xFF\x00<\x00?", "", "utf-16be"},
+		{"<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>", "text/xml; charset=utf-8", "ISO-8859-1"},
+		{"\n<?xml version='1.0' encoding='windows-1251' standalone='yes'?>", "", "windows-1251"},
+		{"<?xml version=\"1.0\" encoding=\"Shift_JIS\"?>", "", "utf-8"},
+		{"<?xml version=\"1.0\" encoding=\"ISO-8859-15\"?><t>Caf\xE9</t>", "text/xml; charset=windows-1252", "windows-1252"},
+		{"<?xml version=\"1.0\"?><t>\xF0\xD2\xC9\xD7\xC5\xD4</t>", "text/xml; charset=koi8-r", "koi8-r"},
+		{"<?xml version=\"1.0\"?><t>Café</t>", "text/xml; charset=ISO-8859-1", "utf-8"},
+		{"<?xml version=\"1.0\"?><t>Caf\xE9</t>", "text/xml; charset=iso-8859-15", "utf-8"},
+		{"<?xml version=\"1.0\"?>", "text/xml", "utf-8"},
+		{"<?xml version=\"1.0\"?>", "invalid; =", "utf-8"},
+		{"{}", "", "utf-8"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, DetectCharset([]byte(testCase.content), testCase.contentType), testCase.content)
+	}
+}
+
+func TestToUTF8(t *testing.T) {
+	testCases := []struct {
+		content  string
+		charset  string
+		expected string
+	}{
+		{"<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><t>Caf\xE9 \x93test\x94</t>", "iso-8859-1", "<?xml version=\"1.0\" encoding=\"UTF-8\"?><t>Café “test”</t>"},
+		{"<?xml version='1.0' encoding='windows-1251'?><t>\xCF\xF0\xE8\xE2\xE5\xF2</t>", "windows-1251", "<?xml version='1.0' encoding='UTF-8'?><t>Привет</t>"},
+		{"<t>\xF0\xD2\xC9\xD7\xC5\xD4</t>", "koi8-r", "<t>Привет</t>"},
+		{"\xFF\xFE<\x00t\x00>\x00\xE9\x00<\x00/\x00t\x00>\x00", "utf-16", "<t>é</t>"},
+		{"\x00<\x00t\x00>\x00\xE9\x00<\x00/\x00t\x00>", "utf-16be", "<t>é</t>"},
+		{"\xEF\xBB\xBF<?xml version=\"1.0\" encoding=\"utf-8\"?><t>é</t>", "utf-8", "<?xml version=\"1.0\" encoding=\"utf-8\"?><t>é</t>"},
+	}
+
+	for _, testCase := range testCases {
+		result, err := ToUTF8([]byte(testCase.content), testCase.charset)
+		assert.Nil(t, err, testCase.charset)
+		assert.Equal(t, testCase.expected, string(result), testCase.charset)
+	}
+
+	// test (error)
+	result, err := ToUTF8([]byte("test"), "shift_jis")
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "unsupported charset: shift_jis")
+}
+
+func TestCharsetReader(t *testing.T) {
+	// test (single-byte)
+	r, err := CharsetReader("windows-1251", iotest.OneByteReader(strings.NewReader("\xCF\xF0\xE8\xE2\xE5\xF2")))
+	assert.Nil(t, err)
+
+	content, err := ioutil.ReadAll(iotest.HalfReader(r))
+	assert.Nil(t, err)
+	assert.Equal(t, "Привет", string(content))
+
+	// test (utf-8)
+	input := strings.NewReader("test")
+	r, err = CharsetReader("UTF-8", input)
+	assert.Nil(t, err)
+	assert.Equal(t, input, r)
+
+	// test (error)
+	r, err = CharsetReader("utf-16", input)
+	assert.Nil(t, r)
+	assert.EqualError(t, err, "unsupported charset: utf-16")
+
+	r, err = CharsetReader("shift_jis", input)
+	assert.Nil(t, r)
+	assert.EqualError(t, err, "unsupported charset: shift_jis")
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	// test (iso-8859-1)
+	var item testLegacyItem
+	err := UnmarshalXML([]byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss><channel><item><title>Caf\xE9</title></item></channel></rss>"), &item)
+	assert.Nil(t, err)
+	assert.Equal(t, "Café", item.Title)
+
+	// test (error)
+	err = UnmarshalXML([]byte("<?xml version=\"1.0\" encoding=\"shift_jis\"?><rss></rss>"), &item)
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package appcaster
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// ReleaseDecoder is the interface that wraps the provider-specific streaming
+// decoders yielding the releases one by one.
+type ReleaseDecoder interface {
+	// Next decodes the next release. Returns io.EOF, if there are no more
+	// releases.
+	//
+	// The release errors are returned alongside the release as Errors. The
+	// release is nil, if it can't be created at all, but the decoding can
+	// continue. Any other error is fatal.
+	Next() (release.Releaser, error)
+}
+
+// DecodeEach decodes the releases using the provided ReleaseDecoder and passes
+// each of them to the provided function alongside with its Errors. The
+// decoding stops when the function returns false, so only the needed part of
+// the feed is read. Returns the fatal decoding error, if any.
+func DecodeEach(d ReleaseDecoder, fn func(r release.Releaser, err error) bool) error {
+	for {
+		r, err := d.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		var errs Errors
+		if err != nil && !errors.As(err, &errs) {
+			return err
+		}
+
+		if !fn(r, err) {
+			return nil
+		}
+	}
+}
+
+// ItemDecoder decodes the XML feed items one by one using the xml.Decoder
+// token iteration. Only the current item is kept in memory, so it can decode
+// the feeds of any size directly from an io.Reader. The legacy single-byte
+// charsets declared in the XML declaration are transcoded on the fly, before
+// the positions are counted, so the positions match the transcoded content.
+type ItemDecoder struct {
+	reader  *positionReader
+	decoder *xml.Decoder
+	name    string
+	index   int
+}
+
+// NewItemDecoder returns a new ItemDecoder instance pointer decoding the
+// elements with the provided local name from the provided reader.
+func NewItemDecoder(r io.Reader, name string) *ItemDecoder {
+	input, transcoded := transcode(r)
+	reader := &positionReader{r: input, line: 1}
+
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = CharsetReader
+
+	if transcoded {
+		// the content is already UTF-8, so the declared charset is ignored
+		decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+	}
+
+	return &ItemDecoder{
+		reader:  reader,
+		decoder: decoder,
+		name:    name,
+	}
+}
+
+// Next decodes the next item into the provided value and returns its 0-based
+// index and position. Returns io.EOF, if there are no more items.
+func (d *ItemDecoder) Next(v interface{}) (int, Position, error) {
+	for {
+		offset := d.decoder.InputOffset()
+
+		token, err := d.decoder.Token()
+		if err != nil {
+			return 0, Position{}, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != d.name {
+			continue
+		}
+
+		position := d.reader.position(offset)
+
+		if err := d.decoder.DecodeElement(v, &start); err != nil {
+			return 0, position, err
+		}
+
+		i := d.index
+		d.index++
+
+		return i, position, nil
+	}
+}
+
+// declarationSize specifies the maximum number of bytes peeked to find the
+// XML declaration.
+const declarationSize = 1024
+
+// transcode returns a reader transcoding the provided reader into UTF-8, if
+// its XML declaration declares a supported single-byte charset. Otherwise, the
+// content is returned as is. The second value reports whether the content is
+// transcoded.
+func transcode(r io.Reader) (io.Reader, bool) {
+	buffered := bufio.NewReader(r)
+	prologue, _ := buffered.Peek(declarationSize)
+
+	charset, err := NormalizeCharset(DetectCharset(prologue, ""))
+	if err != nil || charmaps[charset] == nil {
+		return buffered, false
+	}
+
+	return &charsetReader{r: buffered, charmap: charmaps[charset]}, true
+}
+
+// positionReader wraps an io.Reader and keeps track of the line breaks, so the
+// positions of the increasing offsets can be found without keeping the whole
+// content. Only the line breaks after the last found position are kept.
+type positionReader struct {
+	r         io.Reader
+	offset    int64
+	newlines  []int64
+	line      int
+	lineStart int64
+}
+
+// Read reads from the wrapped reader and records the line breaks offsets.
+func (p *positionReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+
+	for i := 0; i < n; i++ {
+		if b[i] == '\n' {
+			p.newlines = append(p.newlines, p.offset+int64(i))
+		}
+	}
+
+	p.offset += int64(n)
+
+	return n, err
+}
+
+// position returns the position of the provided offset. The offset can't be
+// less than the one from the previous call.
+func (p *positionReader) position(offset int64) Position {
+	passed := 0
+	for passed < len(p.newlines) && p.newlines[passed] < offset {
+		p.lineStart = p.newlines[passed] + 1
+		passed++
+	}
+
+	p.line += passed
+	p.newlines = append(p.newlines[:0], p.newlines[passed:]...)
+
+	return Position{Line: p.line, Column: int(offset-p.lineStart) + 1}
+}
@@ -0,0 +1,160 @@
+package appcaster
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// testDecoder is a ReleaseDecoder returning the predefined results.
+type testDecoder struct {
+	releases []release.Releaser
+	errors   []error
+}
+
+// Next returns the next predefined release and error.
+func (d *testDecoder) Next() (release.Releaser, error) {
+	if len(d.errors) == 0 {
+		return nil, io.EOF
+	}
+
+	r, err := d.releases[0], d.errors[0]
+	d.releases, d.errors = d.releases[1:], d.errors[1:]
+
+	return r, err
+}
+
+// testItem represents a single test feed item.
+type testItem struct {
+	Title string `xml:"title"`
+}
+
+const testItems = "<?xml version=\"1.0\"?>\n<rss>\n  <channel>\n    <item><title>1</title></item>\n    <item>\n      <title>2</title>\n    </item>\n\n  <item><title>3</title></item></channel>\n</rss>\n"
+
+func TestDecodeEach(t *testing.T) {
+	// preparations
+	r1, _ := release.New("2.0.0", "")
+	r2, _ := release.New("1.0.0", "")
+
+	newDecoder := func() *testDecoder {
+		return &testDecoder{
+			releases: []release.Releaser{r1, nil, r2},
+			errors:   []error{nil, Errors{NewReleaseError(1, "version", "", ErrNoVersion)}, nil},
+		}
+	}
+
+	// test
+	var releases []release.Releaser
+	var errs []error
+
+	err := DecodeEach(newDecoder(), func(r release.Releaser, err error) bool {
+		releases = append(releases, r)
+		errs = append(errs, err)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []release.Releaser{r1, nil, r2}, releases)
+	assert.Nil(t, errs[0])
+	assert.EqualError(t, errs[1], "release #2 (no version)")
+
+	// test (early stop)
+	count := 0
+	err = DecodeEach(newDecoder(), func(r release.Releaser, err error) bool {
+		count++
+		return false
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	// test (fatal error)
+	d := &testDecoder{releases: []release.Releaser{nil}, errors: []error{errors.New("fatal")}}
+	err = DecodeEach(d, func(r release.Releaser, err error) bool {
+		t.Fail()
+		return true
+	})
+
+	assert.EqualError(t, err, "fatal")
+}
+
+func TestNewItemDecoder(t *testing.T) {
+	d := NewItemDecoder(bytes.NewReader([]byte(testItems)), "item")
+	assert.IsType(t, ItemDecoder{}, *d)
+	assert.Equal(t, "item", d.name)
+	assert.Equal(t, 0, d.index)
+}
+
+func TestItemDecoder_Next(t *testing.T) {
+	// one byte at a time to make sure the positions don't depend on the reads
+	d := NewItemDecoder(iotest.OneByteReader(bytes.NewReader([]byte(testItems))), "item")
+
+	expected := []struct {
+		title    string
+		position Position
+	}{
+		{"1", Position{Line: 4, Column: 5}},
+		{"2", Position{Line: 5, Column: 5}},
+		{"3", Position{Line: 9, Column: 3}},
+	}
+
+	for i, e := range expected {
+		var item testItem
+
+		index, position, err := d.Next(&item)
+		assert.Nil(t, err)
+		assert.Equal(t, i, index)
+		assert.Equal(t, e.title, item.Title)
+		assert.Equal(t, e.position, position)
+	}
+
+	_, _, err := d.Next(&testItem{})
+	assert.Equal(t, io.EOF, err)
+
+	// test (legacy charset)
+	content := "<?xml version=\"1.0\" encoding=\"windows-1251\"?>\n<rss><title>\xcf\xf0\xe8\xe2\xe5\xf2</title>\n  <item><title>\xcf\xf0\xe8\xe2\xe5\xf2</title></item></rss>"
+	d = NewItemDecoder(iotest.OneByteReader(bytes.NewReader([]byte(content))), "item")
+
+	var item testItem
+
+	_, position, err := d.Next(&item)
+	assert.Nil(t, err)
+	assert.Equal(t, "Привет", item.Title)
+	assert.Equal(t, Position{Line: 3, Column: 3}, position)
+
+	// test (error)
+	d = NewItemDecoder(bytes.NewReader([]byte("<rss><item><title>1</item></rss>")), "item")
+
+	_, _, err = d.Next(&testItem{})
+	assert.Error(t, err)
+
+	// test (error) [read]
+	d = NewItemDecoder(io.MultiReader(bytes.NewReader([]byte("<rss><item><title>1</title></item>")), iotest.ErrReader(errors.New("read failed"))), "item")
+
+	_, _, err = d.Next(&testItem{})
+	assert.Nil(t, err)
+
+	_, _, err = d.Next(&testItem{})
+	assert.EqualError(t, err, "read failed")
+}
+
+func TestPositionReader_Position(t *testing.T) {
+	// preparations
+	p := &positionReader{r: bytes.NewReader([]byte("ab\ncd\n\nef")), line: 1}
+	_, err := io.Copy(ioutil.Discard, p)
+	assert.Nil(t, err)
+
+	// test
+	assert.Equal(t, Position{Line: 1, Column: 1}, p.position(0))
+	assert.Equal(t, Position{Line: 1, Column: 3}, p.position(2))
+	assert.Equal(t, Position{Line: 2, Column: 1}, p.position(3))
+	assert.Equal(t, Position{Line: 4, Column: 2}, p.position(8))
+	assert.Len(t, p.newlines, 0)
+}
@@ -0,0 +1,174 @@
+package appcaster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoVersion is the cause of the ReleaseError when the feed item has neither
+// the version nor the build.
+var ErrNoVersion = errors.New("no version")
+
+// ReleaseError represents an error of a single feed item that occurred while
+// creating its release. Depending on the error, the release is either skipped
+// or kept incomplete.
+//
+// The cause can be retrieved using the Unwrap method, so both the
+// ReleaseError and its cause can be checked with errors.As and errors.Is.
+type ReleaseError struct {
+	// Index specifies the 1-based feed item index.
+	Index int
+
+	// Field specifies the release field that has failed, for example:
+	// "version" or "publishedDateTime".
+	Field string
+
+	// Value specifies the raw field value as found in the feed.
+	Value string
+
+	// Line specifies the 1-based XML line of the feed item. It's 0, if the
+	// position is unknown or the feed isn't XML.
+	Line int
+
+	// Column specifies the 1-based XML column of the feed item. It's 0, if the
+	// position is unknown or the feed isn't XML.
+	Column int
+
+	// Err specifies the underlying cause.
+	Err error
+}
+
+// NewReleaseError returns a new ReleaseError instance pointer for the provided
+// 0-based feed item index, the failed field with its raw value and the
+// underlying cause.
+func NewReleaseError(i int, field string, value string, err error) *ReleaseError {
+	return &ReleaseError{
+		Index: i + 1,
+		Field: field,
+		Value: value,
+		Err:   err,
+	}
+}
+
+// Error returns the string representation of the ReleaseError.
+func (e *ReleaseError) Error() string {
+	return fmt.Sprintf("release #%d (%s)", e.Index, e.Err.Error())
+}
+
+// Unwrap returns the ReleaseError.Err.
+func (e *ReleaseError) Unwrap() error {
+	return e.Err
+}
+
+// Errors represents the multiple errors that occurred while unmarshalling a
+// single appcast.
+//
+// The Appcaster.Unmarshal methods return the errors as []error for
+// compatibility, so they can be converted back using Errors(errs).
+type Errors []error
+
+// Error returns the string representation of all errors separated by "; ".
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns all the errors, so each of them can be checked with
+// errors.As and errors.Is.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Err returns the Errors as a single error. Returns nil, if there are no
+// errors.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// ReleaseErrors returns only the ReleaseError errors.
+func (e Errors) ReleaseErrors() []*ReleaseError {
+	var result []*ReleaseError
+
+	for _, err := range e {
+		var re *ReleaseError
+		if errors.As(err, &re) {
+			result = append(result, re)
+		}
+	}
+
+	return result
+}
+
+// Locate sets the XML line and column of each ReleaseError from the provided
+// feed item positions using its index.
+func (e Errors) Locate(positions []Position) {
+	for _, re := range e.ReleaseErrors() {
+		if re.Index > 0 && re.Index <= len(positions) {
+			re.Line = positions[re.Index-1].Line
+			re.Column = positions[re.Index-1].Column
+		}
+	}
+}
+
+// SetPosition sets the XML line and column of each ReleaseError to the
+// provided position of their single feed item.
+func (e Errors) SetPosition(position Position) {
+	for _, re := range e.ReleaseErrors() {
+		re.Line = position.Line
+		re.Column = position.Column
+	}
+}
+
+// Position represents a position in the source content.
+type Position struct {
+	// Line specifies the 1-based line.
+	Line int
+
+	// Column specifies the 1-based column in bytes.
+	Column int
+}
+
+// LocateElements returns the positions of all XML start elements with the
+// provided local name in the document order. The scanning stops at the first
+// malformed token.
+func LocateElements(content []byte, name string) []Position {
+	var positions []Position
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// only the offsets matter, so the content is kept as is
+		return input, nil
+	}
+
+	for {
+		offset := int(decoder.InputOffset())
+
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		if t, ok := token.(xml.StartElement); ok && t.Name.Local == name {
+			before := content[:offset]
+			positions = append(positions, Position{
+				Line:   bytes.Count(before, []byte("\n")) + 1,
+				Column: offset - bytes.LastIndexByte(before, '\n'),
+			})
+		}
+	}
+
+	return positions
+}
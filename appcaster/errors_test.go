@@ -0,0 +1,121 @@
+package appcaster
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReleaseError(t *testing.T) {
+	err := NewReleaseError(1, "version", "invalid", errors.New("malformed version: invalid"))
+	assert.IsType(t, ReleaseError{}, *err)
+	assert.Equal(t, 2, err.Index)
+	assert.Equal(t, "version", err.Field)
+	assert.Equal(t, "invalid", err.Value)
+	assert.Equal(t, 0, err.Line)
+	assert.Equal(t, 0, err.Column)
+	assert.EqualError(t, err.Err, "malformed version: invalid")
+}
+
+func TestReleaseError_Error(t *testing.T) {
+	err := NewReleaseError(0, "version", "", ErrNoVersion)
+	assert.Equal(t, "release #1 (no version)", err.Error())
+}
+
+func TestReleaseError_Unwrap(t *testing.T) {
+	// preparations
+	var err error = NewReleaseError(0, "version", "", ErrNoVersion)
+
+	// test
+	assert.Equal(t, ErrNoVersion, err.(*ReleaseError).Unwrap())
+	assert.True(t, errors.Is(err, ErrNoVersion))
+}
+
+func TestErrors_Error(t *testing.T) {
+	errs := Errors{
+		NewReleaseError(0, "version", "", ErrNoVersion),
+		errors.New("test"),
+	}
+
+	assert.Equal(t, "release #1 (no version); test", errs.Error())
+	assert.Equal(t, "", Errors{}.Error())
+}
+
+func TestErrors_Unwrap(t *testing.T) {
+	// preparations
+	errs := Errors{
+		errors.New("test"),
+		NewReleaseError(1, "publishedDateTime", "invalid", errors.New("parsing of the published datetime failed")),
+	}
+
+	// test
+	var re *ReleaseError
+	assert.True(t, errors.As(errs, &re))
+	assert.Equal(t, 2, re.Index)
+	assert.Equal(t, "publishedDateTime", re.Field)
+}
+
+func TestErrors_Err(t *testing.T) {
+	assert.Nil(t, Errors{}.Err())
+	assert.Nil(t, Errors(nil).Err())
+
+	errs := Errors{errors.New("test")}
+	assert.Equal(t, errs, errs.Err())
+}
+
+func TestErrors_ReleaseErrors(t *testing.T) {
+	// preparations
+	re := NewReleaseError(0, "version", "", ErrNoVersion)
+	errs := Errors{errors.New("test"), re}
+
+	// test
+	assert.Equal(t, []*ReleaseError{re}, errs.ReleaseErrors())
+	assert.Nil(t, Errors{errors.New("test")}.ReleaseErrors())
+
+	// test (wrapped)
+	assert.Equal(t, []*ReleaseError{re}, Errors{fmt.Errorf("feed: %w", re)}.ReleaseErrors())
+}
+
+func TestErrors_Locate(t *testing.T) {
+	// preparations
+	errs := Errors{
+		NewReleaseError(0, "version", "", ErrNoVersion),
+		NewReleaseError(2, "version", "", ErrNoVersion),
+		errors.New("test"),
+	}
+
+	// test
+	errs.Locate([]Position{{Line: 8, Column: 5}, {Line: 15, Column: 5}})
+
+	assert.Equal(t, 8, errs[0].(*ReleaseError).Line)
+	assert.Equal(t, 5, errs[0].(*ReleaseError).Column)
+	assert.Equal(t, 0, errs[1].(*ReleaseError).Line)
+	assert.Equal(t, 0, errs[1].(*ReleaseError).Column)
+}
+
+func TestErrors_SetPosition(t *testing.T) {
+	// preparations
+	errs := Errors{
+		NewReleaseError(0, "version", "", ErrNoVersion),
+		errors.New("test"),
+	}
+
+	// test
+	errs.SetPosition(Position{Line: 8, Column: 5})
+
+	assert.Equal(t, 8, errs[0].(*ReleaseError).Line)
+	assert.Equal(t, 5, errs[0].(*ReleaseError).Column)
+}
+
+func TestLocateElements(t *testing.T) {
+	// preparations
+	content := []byte("<?xml version=\"1.0\"?>\n<rss>\n  <channel>\n    <item><title>1</title></item>\n    <item>\n      <title>2</title>\n    </item>\n  </channel>\n</rss>\n")
+
+	// test
+	assert.Equal(t, []Position{{Line: 4, Column: 5}, {Line: 5, Column: 5}}, LocateElements(content, "item"))
+	assert.Equal(t, []Position{{Line: 2, Column: 1}}, LocateElements(content, "rss"))
+	assert.Nil(t, LocateElements(content, "entry"))
+	assert.Nil(t, LocateElements([]byte("{}"), "item"))
+}
@@ -0,0 +1,262 @@
+package appcaster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Repair represents a single repair of the malformed XML content made in the
+// lenient mode. It's returned alongside the release errors as a warning, so
+// the feed isn't failed as a whole.
+type Repair struct {
+	// Line specifies the 1-based line of the repaired content.
+	Line int
+
+	// Column specifies the 1-based column of the repaired content in bytes. It's
+	// 0, if unknown.
+	Column int
+
+	// Message specifies the repair description.
+	Message string
+}
+
+// Error returns the string representation of the Repair.
+func (r *Repair) Error() string {
+	return fmt.Sprintf("line %d: %s", r.Line, r.Message)
+}
+
+// lenientHTMLElements holds the local names of the elements that usually hold
+// the HTML markup.
+var lenientHTMLElements = map[string]bool{
+	"description": true,
+	"content":     true,
+	"summary":     true,
+}
+
+// xmlEntities holds the predefined XML entities.
+var xmlEntities = map[string]bool{
+	"amp":  true,
+	"lt":   true,
+	"gt":   true,
+	"quot": true,
+	"apos": true,
+}
+
+var (
+	// entityRegexp matches the entity reference at the beginning.
+	entityRegexp = regexp.MustCompile(`^&(#[0-9]+|#[xX][0-9a-fA-F]+|[A-Za-z][A-Za-z0-9]*);`)
+
+	// startTagRegexp matches the start tag at the beginning.
+	startTagRegexp = regexp.MustCompile(`^<([A-Za-z_][\w.-]*:)?([A-Za-z_][\w.-]*)(\s[^<>]*)?>`)
+
+	// tagRegexp matches any start, end or empty element tag.
+	tagRegexp = regexp.MustCompile(`<(/)?([A-Za-z_][\w:.-]*)(?:\s[^<>]*?)?(/)?>`)
+)
+
+// Sanitize repairs the most common XML errors of the real-world feeds in the
+// provided content and returns the repaired content alongside with the
+// repairs. The number of lines is preserved, so the positions stay the same.
+//
+// The following errors are repaired:
+//
+//   - the unescaped "&" characters are escaped
+//   - the HTML entities, like "&nbsp;", are replaced with the numeric ones
+//   - the unbalanced HTML markup of the "description", "content" and "summary"
+//     elements is wrapped in CDATA
+//
+// The CDATA sections and comments are kept as is.
+func Sanitize(content []byte) ([]byte, []*Repair) {
+	var repairs []*Repair
+
+	result := make([]byte, 0, len(content))
+	line, lineStart := 1, 0
+
+	repair := func(i int, format string, a ...interface{}) {
+		repairs = append(repairs, &Repair{
+			Line:    line,
+			Column:  i - lineStart + 1,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	// advance moves to the provided end keeping track of the lines
+	advance := func(i int, end int) int {
+		for j := i; j < end; j++ {
+			if content[j] == '\n' {
+				line, lineStart = line+1, j+1
+			}
+		}
+
+		return end
+	}
+
+	// keep copies the content up to the provided end as is
+	keep := func(i int, end int) int {
+		result = append(result, content[i:end]...)
+		return advance(i, end)
+	}
+
+	for i := 0; i < len(content); {
+		rest := content[i:]
+
+		switch {
+		case bytes.HasPrefix(rest, []byte("<![CDATA[")):
+			i = keep(i, i+sectionEnd(rest, "]]>"))
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			i = keep(i, i+sectionEnd(rest, "-->"))
+		case rest[0] == '<':
+			m := startTagRegexp.FindSubmatch(rest)
+			if m == nil || !lenientHTMLElements[string(m[2])] || bytes.HasSuffix(m[0], []byte("/>")) {
+				result = append(result, '<')
+				i++
+				continue
+			}
+
+			start := i + len(m[0])
+			end := bytes.Index(content[start:], []byte("</"+string(m[1])+string(m[2])+">"))
+			if end < 0 {
+				result = append(result, '<')
+				i++
+				continue
+			}
+
+			inner := content[start : start+end]
+			if bytes.Contains(inner, []byte("<![CDATA[")) || balanced(inner) {
+				result = append(result, '<')
+				i++
+				continue
+			}
+
+			repair(i, "unbalanced markup of the \"%s\" element wrapped in CDATA", m[2])
+
+			i = keep(i, start)
+			result = append(result, "<![CDATA["...)
+			result = append(result, bytes.Replace(inner, []byte("]]>"), []byte("]]]]><![CDATA[>"), -1)...)
+			result = append(result, "]]>"...)
+			i = advance(i, start+end)
+		case rest[0] == '&':
+			m := entityRegexp.FindSubmatch(rest)
+
+			switch {
+			case m == nil:
+				repair(i, "unescaped \"&\" escaped")
+				result = append(result, "&amp;"...)
+				i++
+			case m[1][0] == '#' || xmlEntities[string(m[1])]:
+				i = keep(i, i+len(m[0]))
+			default:
+				unescaped := html.UnescapeString(string(m[0]))
+				if unescaped == string(m[0]) {
+					repair(i, "unknown entity \"%s\" escaped", m[0])
+					result = append(result, "&amp;"...)
+					i++
+					continue
+				}
+
+				var numeric strings.Builder
+				for _, r := range unescaped {
+					fmt.Fprintf(&numeric, "&#%d;", r)
+				}
+
+				repair(i, "HTML entity \"%s\" replaced with \"%s\"", m[0], numeric.String())
+				result = append(result, numeric.String()...)
+				i += len(m[0])
+			}
+		default:
+			i = keep(i, i+1)
+		}
+	}
+
+	return result, repairs
+}
+
+// sectionEnd returns the length of the section starting the provided content
+// and ending with the provided terminator. Returns the content length, if the
+// section isn't terminated.
+func sectionEnd(content []byte, terminator string) int {
+	end := bytes.Index(content, []byte(terminator))
+	if end < 0 {
+		return len(content)
+	}
+
+	return end + len(terminator)
+}
+
+// balanced checks whether all tags of the provided markup are closed in the
+// right order.
+func balanced(markup []byte) bool {
+	var stack []string
+
+	for _, m := range tagRegexp.FindAllSubmatch(markup, -1) {
+		name := string(m[2])
+
+		switch {
+		case len(m[3]) > 0:
+			continue
+		case len(m[1]) > 0:
+			if len(stack) == 0 || stack[len(stack)-1] != name {
+				return false
+			}
+
+			stack = stack[:len(stack)-1]
+		default:
+			stack = append(stack, name)
+		}
+	}
+
+	return len(stack) == 0
+}
+
+// UnmarshalXMLLenient parses the provided XML content into the provided
+// pointer value just like UnmarshalXML does, but recovers as much as possible
+// from the malformed content. Returns the repaired content, which should be
+// used for locating the elements, and the repairs as Errors.
+//
+// The content is repaired by Sanitize first. If it's still malformed, the
+// syntax error is reported as a repair and the content is parsed again by the
+// non-strict decoder, which closes the unclosed tags and knows the HTML
+// entities. Returns an error, if it can't be parsed even then.
+func UnmarshalXMLLenient(content []byte, v interface{}) ([]byte, Errors, error) {
+	var errs Errors
+
+	content, repairs := Sanitize(content)
+	for _, r := range repairs {
+		errs = append(errs, r)
+	}
+
+	err := UnmarshalXML(content, v)
+	if err == nil {
+		return content, errs, nil
+	}
+
+	syntaxErr, ok := err.(*xml.SyntaxError)
+	if !ok {
+		return content, errs, err
+	}
+
+	errs = append(errs, &Repair{
+		Line:    syntaxErr.Line,
+		Message: fmt.Sprintf("recovered from the malformed XML: %s", syntaxErr.Msg),
+	})
+
+	// the partially decoded value is reset before decoding it again
+	value := reflect.ValueOf(v).Elem()
+	value.Set(reflect.Zero(value.Type()))
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.CharsetReader = CharsetReader
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	if err := decoder.Decode(v); err != nil {
+		return content, errs, err
+	}
+
+	return content, errs, nil
+}
@@ -0,0 +1,114 @@
+package appcaster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLenientFeed represents a test feed for the lenient unmarshalling.
+type testLenientFeed struct {
+	Items []struct {
+		Title       string `xml:"title"`
+		Description string `xml:"description"`
+		Link        string `xml:"link"`
+	} `xml:"channel>item"`
+}
+
+func TestRepair_Error(t *testing.T) {
+	r := &Repair{Line: 5, Column: 10, Message: "unescaped \"&\" escaped"}
+	assert.Equal(t, "line 5: unescaped \"&\" escaped", r.Error())
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		content  string
+		expected string
+		repairs  []*Repair
+	}{
+		{
+			"<t>A & B</t>",
+			"<t>A &amp; B</t>",
+			[]*Repair{{Line: 1, Column: 6, Message: "unescaped \"&\" escaped"}},
+		},
+		{
+			"<t>\n  A&nbsp;B &copy; &foo; &amp; &#160; &#xA0;\n</t>",
+			"<t>\n  A&#160;B &#169; &amp;foo; &amp; &#160; &#xA0;\n</t>",
+			[]*Repair{
+				{Line: 2, Column: 4, Message: "HTML entity \"&nbsp;\" replaced with \"&#160;\""},
+				{Line: 2, Column: 12, Message: "HTML entity \"&copy;\" replaced with \"&#169;\""},
+				{Line: 2, Column: 19, Message: "unknown entity \"&foo;\" escaped"},
+			},
+		},
+		{
+			"<item>\n<description><p>A & B<br></p>\n</description></item>",
+			"<item>\n<description><![CDATA[<p>A & B<br></p>\n]]></description></item>",
+			[]*Repair{{Line: 2, Column: 1, Message: "unbalanced markup of the \"description\" element wrapped in CDATA"}},
+		},
+		{
+			"<atom:content type=\"html\"><b>]]></atom:content>",
+			"<atom:content type=\"html\"><![CDATA[<b>]]]]><![CDATA[>]]></atom:content>",
+			[]*Repair{{Line: 1, Column: 1, Message: "unbalanced markup of the \"content\" element wrapped in CDATA"}},
+		},
+		{
+			"<description><p>A <br/> B</p></description><description/><description>",
+			"<description><p>A <br/> B</p></description><description/><description>",
+			nil,
+		},
+		{
+			"<!-- A & B --><t><![CDATA[A & B<br>]]></t><description><![CDATA[<br>]]></description>",
+			"<!-- A & B --><t><![CDATA[A & B<br>]]></t><description><![CDATA[<br>]]></description>",
+			nil,
+		},
+		{
+			"<!-- A & B",
+			"<!-- A & B",
+			nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, repairs := Sanitize([]byte(testCase.content))
+		assert.Equal(t, testCase.expected, string(result), testCase.content)
+		assert.Equal(t, testCase.repairs, repairs, testCase.content)
+	}
+}
+
+func TestUnmarshalXMLLenient(t *testing.T) {
+	// preparations
+	content := "<rss>\n<channel>\n<item>\n<title>A &amp; B&nbsp;1.0.0</title>\n<description><p>Fixes & improvements<br></description>\n<link>https://example.com/?a=1&b=2</link>\n</item>\n</channel>\n</rss>\n"
+
+	// test (sanitized)
+	var feed testLenientFeed
+	result, errs, err := UnmarshalXMLLenient([]byte(content), &feed)
+	assert.Nil(t, err)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, 4, errs[0].(*Repair).Line)
+	assert.Equal(t, 5, errs[1].(*Repair).Line)
+	assert.Equal(t, 6, errs[2].(*Repair).Line)
+	assert.Contains(t, string(result), "<![CDATA[")
+	assert.Len(t, feed.Items, 1)
+	assert.Equal(t, "A & B 1.0.0", feed.Items[0].Title)
+	assert.Equal(t, "<p>Fixes & improvements<br>", feed.Items[0].Description)
+	assert.Equal(t, "https://example.com/?a=1&b=2", feed.Items[0].Link)
+
+	// test (non-strict)
+	feed = testLenientFeed{}
+	content = "<rss>\n<channel>\n<item><title>1.0.0</title></item>\n<item><title>2.0.0<b></title></item>\n</channel>\n</rss>\n"
+
+	_, errs, err = UnmarshalXMLLenient([]byte(content), &feed)
+	assert.Nil(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 4, errs[0].(*Repair).Line)
+	assert.Contains(t, errs[0].Error(), "recovered from the malformed XML: ")
+	assert.Len(t, feed.Items, 2)
+	assert.Equal(t, "1.0.0", feed.Items[0].Title)
+
+	// test (error)
+	_, _, err = UnmarshalXMLLenient([]byte("<?xml version=\"1.0\" encoding=\"shift_jis\"?><rss></rss>"), &feed)
+	assert.Error(t, err)
+
+	_, errs, err = UnmarshalXMLLenient([]byte("<rss><channel>"), &feed)
+	assert.Error(t, err)
+	assert.Len(t, errs, 1)
+}
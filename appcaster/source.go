@@ -25,6 +25,12 @@ type Source struct {
 	// and releases will be extracted.
 	content []byte
 
+	// charset specifies the original charset label of the Source.content
+	// before it has been transcoded into UTF-8.
+	//
+	// This value is set by calling Source.NormalizeContent.
+	charset string
+
 	// checksum specifies the Checksum pointer that hold a checksum data about
 	// the Source.content.
 	//
@@ -61,6 +67,38 @@ func (s *Source) GenerateChecksum(algorithm ChecksumAlgorithm) *Checksum {
 	return c
 }
 
+// NormalizeContent detects the charset of the Source.content using the BOM,
+// XML declaration or the provided "Content-Type" header value and transcodes
+// the content into UTF-8. The detected charset is kept in the Source.charset.
+// This method should be called right after the content has been successfully
+// loaded, before the Source.GenerateChecksum.
+//
+// The content declaring an unsupported charset is left untouched. Returns an
+// error, if the detected charset can't be transcoded.
+func (s *Source) NormalizeContent(contentType ...string) error {
+	var ct string
+	if len(contentType) > 0 {
+		ct = contentType[0]
+	}
+
+	charset := DetectCharset(s.content, ct)
+
+	content, err := ToUTF8(s.content, charset)
+	if err != nil {
+		return err
+	}
+
+	s.content = content
+	s.charset = charset
+
+	return nil
+}
+
+// Charset is a Source.charset getter.
+func (s *Source) Charset() string {
+	return s.charset
+}
+
 // Content is a Source.content getter.
 func (s *Source) Content() []byte {
 	return s.content
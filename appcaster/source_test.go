@@ -43,6 +43,60 @@ func TestSource_GenerateChecksum(t *testing.T) {
 	assert.Equal(t, expected, src.Checksum().String())
 }
 
+func TestSource_NormalizeContent(t *testing.T) {
+	// test (xml declaration)
+	src := newTestSource()
+	src.SetContent([]byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><t>Caf\xE9</t>"))
+	err := src.NormalizeContent()
+	assert.Nil(t, err)
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><t>Café</t>", string(src.content))
+	assert.Equal(t, "ISO-8859-1", src.charset)
+
+	// test (content type)
+	src = newTestSource()
+	src.SetContent([]byte("<t>\xCF\xF0\xE8\xE2\xE5\xF2</t>"))
+	err = src.NormalizeContent("text/xml; charset=windows-1251")
+	assert.Nil(t, err)
+	assert.Equal(t, "<t>Привет</t>", string(src.content))
+	assert.Equal(t, "windows-1251", src.charset)
+
+	// test (content type) [valid utf-8]
+	src = newTestSource()
+	src.SetContent([]byte("<t>Café</t>"))
+	err = src.NormalizeContent("text/xml; charset=ISO-8859-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "<t>Café</t>", string(src.content))
+	assert.Equal(t, "utf-8", src.charset)
+
+	// test (content type) [unsupported]
+	src = newTestSource()
+	src.SetContent([]byte("<t>Caf\xE9</t>"))
+	err = src.NormalizeContent("text/xml; charset=iso-8859-15")
+	assert.Nil(t, err)
+	assert.Equal(t, "utf-8", src.charset)
+
+	// test (utf-8)
+	src = newTestSource()
+	err = src.NormalizeContent()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("content"), src.content)
+	assert.Equal(t, "utf-8", src.charset)
+
+	// test (declaration) [unsupported]
+	src = newTestSource()
+	src.SetContent([]byte("<?xml version=\"1.0\" encoding=\"shift_jis\"?>"))
+	err = src.NormalizeContent()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("<?xml version=\"1.0\" encoding=\"shift_jis\"?>"), src.content)
+	assert.Equal(t, "utf-8", src.charset)
+}
+
+func TestSource_Charset(t *testing.T) {
+	src := newTestSource()
+	src.charset = "windows-1251"
+	assert.Equal(t, "windows-1251", src.Charset())
+}
+
 func TestSource_Content(t *testing.T) {
 	src := newTestSource()
 	assert.Equal(t, src.content, src.Content())
@@ -0,0 +1,136 @@
+package appcaster
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+)
+
+// UncommentItems uncomments the commented out feed items with the provided
+// local names in the provided XML content. Only the comments placed between
+// the items and holding nothing but the whole items are uncommented. Other
+// comments, including the ones inside the items, the CDATA sections and the
+// text are kept as is.
+//
+// Returns the uncommented content and the 0-based indexes of the items that
+// have been commented out. Only the comment delimiters are removed, so the
+// positions of all lines stay the same. The scanning stops at the first
+// malformed token.
+func UncommentItems(content []byte, names ...string) ([]byte, []int) {
+	var result []byte
+	var indexes []int
+
+	isItem := func(name xml.Name) bool {
+		for _, n := range names {
+			if name.Local == n {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// only the offsets matter, so the content is kept as is
+		return input, nil
+	}
+
+	depth, last, count := 0, 0, 0
+
+	for {
+		offset := int(decoder.InputOffset())
+
+		token, err := decoder.RawToken()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 && isItem(t.Name) {
+				depth = 1
+				count++
+			} else if depth > 0 {
+				depth++
+			}
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+		case xml.Comment:
+			if depth > 0 {
+				continue
+			}
+
+			items, ok := countItems(t, isItem)
+			if !ok {
+				continue
+			}
+
+			for i := 0; i < items; i++ {
+				indexes = append(indexes, count)
+				count++
+			}
+
+			end := int(decoder.InputOffset())
+			result = append(result, content[last:offset]...)
+			result = append(result, content[offset+len("<!--"):end-len("-->")]...)
+			last = end
+		}
+	}
+
+	if result == nil {
+		return content, nil
+	}
+
+	return append(result, content[last:]...), indexes
+}
+
+// countItems checks whether the provided comment holds nothing but the whole
+// items and counts them using the provided function. The comment is parsed in
+// the non-strict mode with the HTML entities, as the commented out markup
+// isn't validated by anyone, but the elements still have to be balanced.
+func countItems(comment xml.Comment, isItem func(name xml.Name) bool) (int, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(comment))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var open []xml.Name
+	items := 0
+
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, false
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(open) == 0 {
+				if !isItem(t.Name) {
+					return 0, false
+				}
+
+				items++
+			}
+
+			open = append(open, t.Name)
+		case xml.EndElement:
+			if len(open) == 0 || open[len(open)-1] != t.Name {
+				return 0, false
+			}
+
+			open = open[:len(open)-1]
+		case xml.CharData:
+			if len(open) == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return 0, false
+			}
+		}
+	}
+
+	return items, items > 0 && len(open) == 0
+}
@@ -0,0 +1,94 @@
+package appcaster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUncommentItems(t *testing.T) {
+	testCases := []struct {
+		content  string
+		names    []string
+		expected string
+		indexes  []int
+	}{
+		// whole items
+		{
+			"<rss><channel>\n<!-- <item><title>2.0.0</title></item>\n<item><title>1.1.0</title></item> -->\n<item><title>1.0.0</title></item>\n</channel></rss>",
+			[]string{"item"},
+			"<rss><channel>\n <item><title>2.0.0</title></item>\n<item><title>1.1.0</title></item> \n<item><title>1.0.0</title></item>\n</channel></rss>",
+			[]int{0, 1},
+		},
+		// HTML entities
+		{
+			"<rss><channel>\n<!-- <item><title>2.0.0&nbsp;Beta</title></item> -->\n</channel></rss>",
+			[]string{"item"},
+			"<rss><channel>\n <item><title>2.0.0&nbsp;Beta</title></item> \n</channel></rss>",
+			[]int{0},
+		},
+		// the commented out markup inside the item is kept
+		{
+			"<rss><channel>\n<item><title>2.0.0</title><!-- <sparkle:releaseNotesLink>a</sparkle:releaseNotesLink> --></item>\n<!-- <item><title>1.0.0</title><!--<enclosure url=\"b\" />--></item> -->\n</channel></rss>",
+			[]string{"item"},
+			"<rss><channel>\n<item><title>2.0.0</title><!-- <sparkle:releaseNotesLink>a</sparkle:releaseNotesLink> --></item>\n<!-- <item><title>1.0.0</title><!--<enclosure url=\"b\" />--></item> -->\n</channel></rss>",
+			nil,
+		},
+		// multiple names
+		{
+			"<feed><!-- <entry><id>1</id></entry> --><item><!-- <title>2</title> --></item><!-- <item><title>3</title></item> --></feed>",
+			[]string{"item", "entry"},
+			"<feed> <entry><id>1</id></entry> <item><!-- <title>2</title> --></item> <item><title>3</title></item> </feed>",
+			[]int{0, 2},
+		},
+		// the comment mixing items with other markup is kept
+		{
+			"<rss><channel><!-- <title>Old</title><item><title>1.0.0</title></item> --></channel></rss>",
+			[]string{"item"},
+			"<rss><channel><!-- <title>Old</title><item><title>1.0.0</title></item> --></channel></rss>",
+			nil,
+		},
+		// the text comment is kept
+		{
+			"<rss><channel><!-- the old releases --><item><!-- TODO: add <enclosure> --></item></channel></rss>",
+			[]string{"item"},
+			"<rss><channel><!-- the old releases --><item><!-- TODO: add <enclosure> --></item></channel></rss>",
+			nil,
+		},
+		// the comment without items outside of items is kept
+		{
+			"<rss><channel><!-- <title>Old</title> --><item><title>1.0.0</title></item></channel></rss>",
+			[]string{"item"},
+			"<rss><channel><!-- <title>Old</title> --><item><title>1.0.0</title></item></channel></rss>",
+			nil,
+		},
+		// the comment-like CDATA text is kept
+		{
+			"<rss><channel><item><description><![CDATA[<!-- <b>note</b> -->]]></description></item></channel></rss>",
+			[]string{"item"},
+			"<rss><channel><item><description><![CDATA[<!-- <b>note</b> -->]]></description></item></channel></rss>",
+			nil,
+		},
+		// the malformed markup is kept
+		{
+			"<rss><channel><!-- <item><title>2.0.0</item> --><item><!-- <enclosure url=\"a\"> --></item></channel></rss>",
+			[]string{"item"},
+			"<rss><channel><!-- <item><title>2.0.0</item> --><item><!-- <enclosure url=\"a\"> --></item></channel></rss>",
+			nil,
+		},
+		// other names
+		{
+			"<rss><channel><!-- <item><title>2.0.0</title></item> --></channel></rss>",
+			[]string{"entry"},
+			"<rss><channel><!-- <item><title>2.0.0</title></item> --></channel></rss>",
+			nil,
+		},
+	}
+
+	// test
+	for _, testCase := range testCases {
+		content, indexes := UncommentItems([]byte(testCase.content), testCase.names...)
+		assert.Equal(t, testCase.expected, string(content))
+		assert.Equal(t, testCase.indexes, indexes)
+	}
+}
@@ -19,7 +19,7 @@ func TestNewRequest(t *testing.T) {
 	r, err = NewRequest(url)
 	assert.Nil(t, r)
 	assert.Error(t, err)
-	assert.EqualError(t, err, fmt.Sprintf("parse %s: invalid URL escape \"%%31\"", url))
+	assert.EqualError(t, err, fmt.Sprintf("parse %q: invalid URL escape \"%%31\"", url))
 }
 
 func TestRequest_AddHeader(t *testing.T) {
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider/generic"
+	"github.com/victorpopkov/go-appcast/provider/jsonfeed"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// inspectResult represents the "inspect" command JSON output.
+type inspectResult struct {
+	Source     string            `json:"source"`
+	Provider   string            `json:"provider"`
+	Candidates []candidateResult `json:"candidates,omitempty"`
+	Checksum   string            `json:"checksum,omitempty"`
+	Channel    *channelResult    `json:"channel,omitempty"`
+	Releases   []releaseResult   `json:"releases"`
+	Errors     []string          `json:"errors,omitempty"`
+}
+
+// candidateResult represents a single provider candidate JSON output.
+type candidateResult struct {
+	Provider   string   `json:"provider"`
+	Confidence float64  `json:"confidence"`
+	Reasons    []string `json:"reasons,omitempty"`
+}
+
+// channelResult represents the appcast channel information JSON output.
+type channelResult struct {
+	Title       string `json:"title,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"`
+}
+
+// releaseResult represents a single release JSON output.
+type releaseResult struct {
+	Version           string           `json:"version,omitempty"`
+	Build             string           `json:"build,omitempty"`
+	Title             string           `json:"title,omitempty"`
+	PublishedDateTime *time.Time       `json:"published_datetime,omitempty"`
+	IsPreRelease      bool             `json:"prerelease"`
+	Downloads         []downloadResult `json:"downloads,omitempty"`
+}
+
+// downloadResult represents a single release download JSON output.
+type downloadResult struct {
+	Url      string `json:"url"`
+	Filetype string `json:"filetype,omitempty"`
+	Length   int    `json:"length,omitempty"`
+}
+
+// runInspect runs the "inspect" command which prints the detected provider,
+// channel information and releases table.
+func runInspect(args []string, stdout io.Writer, stderr io.Writer) int {
+	o := new(options)
+	fs := newFlagSet("inspect", stderr, o)
+
+	target, status := parse(fs, args, stderr)
+	if status >= 0 {
+		return status
+	}
+
+	a, p, errs, err := load(target, o)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if err = filter(a.Releases(), o); err != nil {
+		return fail(stderr, err)
+	}
+
+	result := inspectResult{
+		Source:   target,
+		Provider: a.Source().Provider().String(),
+		Channel:  newChannelResult(p),
+		Releases: []releaseResult{},
+	}
+
+	for _, c := range a.Candidates() {
+		result.Candidates = append(result.Candidates, candidateResult{
+			Provider:   c.Provider.String(),
+			Confidence: c.Confidence,
+			Reasons:    c.Reasons,
+		})
+	}
+
+	if a.Source().Checksum() != nil {
+		result.Checksum = a.Source().Checksum().String()
+	}
+
+	for _, r := range a.Releases().Filtered() {
+		result.Releases = append(result.Releases, newReleaseResult(r))
+	}
+
+	if o.json {
+		for _, err := range errs {
+			result.Errors = append(result.Errors, err.Error())
+		}
+
+		return printJSON(stdout, stderr, result)
+	}
+
+	warn(stderr, errs)
+	printInspect(stdout, result)
+
+	return exitSuccess
+}
+
+// printInspect prints the provided "inspect" command result as a text.
+func printInspect(w io.Writer, result inspectResult) {
+	fmt.Fprintf(w, "%-9s %s\n", "Source:", result.Source)
+	fmt.Fprintf(w, "%-9s %s\n", "Provider:", result.Provider)
+
+	if result.Checksum != "" {
+		fmt.Fprintf(w, "%-9s %s\n", "Checksum:", result.Checksum)
+	}
+
+	if c := result.Channel; c != nil {
+		for _, line := range [][2]string{
+			{"Title:", c.Title},
+			{"Link:", c.Link},
+			{"Language:", c.Language},
+		} {
+			if line[1] != "" {
+				fmt.Fprintf(w, "%-9s %s\n", line[0], line[1])
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%-9s %d total\n", "Releases:", len(result.Releases))
+
+	if len(result.Releases) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tBUILD\tPUBLISHED\tPRE-RELEASE\tDOWNLOADS")
+
+	for _, r := range result.Releases {
+		published := "-"
+		if r.PublishedDateTime != nil {
+			published = r.PublishedDateTime.Format("2006-01-02")
+		}
+
+		pre := "no"
+		if r.IsPreRelease {
+			pre = "yes"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\n", dash(r.Version), dash(r.Build), published, pre, len(r.Downloads))
+	}
+
+	tw.Flush()
+}
+
+// runLatest runs the "latest" command which prints the newest stable release
+// version and its download URL.
+func runLatest(args []string, stdout io.Writer, stderr io.Writer) int {
+	o := new(options)
+	fs := newFlagSet("latest", stderr, o)
+
+	prerelease := fs.Bool("prerelease", false, "consider the pre-releases as well")
+
+	target, status := parse(fs, args, stderr)
+	if status >= 0 {
+		return status
+	}
+
+	a, _, errs, err := load(target, o)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	releases := a.Releases()
+
+	if err = filter(releases, o); err != nil {
+		return fail(stderr, err)
+	}
+
+	if !*prerelease {
+		releases.FilterByPrerelease(true)
+	}
+
+	releases.SortByVersions(release.DESC)
+
+	if len(releases.Filtered()) == 0 {
+		warn(stderr, errs)
+		return fail(stderr, fmt.Errorf("no releases found"))
+	}
+
+	result := newReleaseResult(releases.First())
+
+	if o.json {
+		return printJSON(stdout, stderr, result)
+	}
+
+	warn(stderr, errs)
+
+	url := ""
+	if len(result.Downloads) > 0 {
+		url = result.Downloads[0].Url
+	}
+
+	version := result.Version
+	if version == "" {
+		version = result.Build
+	}
+
+	fmt.Fprintf(stdout, "%s\t%s\n", version, url)
+
+	return exitSuccess
+}
+
+// newChannelResult returns a new channelResult instance pointer created from
+// the provided provider-specific appcast. Returns nil, if the provider has no
+// channel information.
+func newChannelResult(p appcaster.Appcaster) *channelResult {
+	switch a := p.(type) {
+	case *sparkle.Appcast:
+		if c := a.Channel(); c != nil {
+			return &channelResult{c.Title, c.Link, c.Description, c.Language}
+		}
+	case *generic.Appcast:
+		if c := a.Channel(); c != nil {
+			return &channelResult{Title: c.Title, Link: c.Link, Description: c.Description}
+		}
+	case *jsonfeed.Appcast:
+		if f := a.Feed(); f != nil {
+			return &channelResult{f.Title, f.HomePageUrl, f.Description, f.Language}
+		}
+	}
+
+	return nil
+}
+
+// newReleaseResult returns a new releaseResult created from the provided
+// release.
+func newReleaseResult(r release.Releaser) releaseResult {
+	result := releaseResult{
+		Build:        r.Build(),
+		Title:        r.Title(),
+		IsPreRelease: r.IsPreRelease(),
+	}
+
+	if r.Version() != nil {
+		result.Version = r.Version().Original()
+	}
+
+	if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+		t := *p.Time()
+		result.PublishedDateTime = &t
+	}
+
+	for _, d := range r.Downloads() {
+		result.Downloads = append(result.Downloads, downloadResult{
+			Url:      d.Url(),
+			Filetype: d.Filetype(),
+			Length:   d.Length(),
+		})
+	}
+
+	return result
+}
+
+// printJSON prints the provided value as an indented JSON and returns the exit
+// status.
+func printJSON(stdout io.Writer, stderr io.Writer, v interface{}) int {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitSuccess
+}
+
+// dash returns the provided value or "-", if the value is empty.
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+func TestRunInspect(t *testing.T) {
+	// mock the request
+	content, _ := ioutil.ReadFile(testdataPath("github.xml"))
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", "https://github.com/atom/atom/releases.atom", httpmock.NewBytesResponder(200, content))
+	defer httpmock.DeactivateAndReset()
+
+	// test (local)
+	status, stdout, stderr := runTest("inspect", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+	assert.Empty(t, stderr)
+	assert.Contains(t, stdout, "Provider: Sparkle RSS Feed\n")
+	assert.Contains(t, stdout, "Title:    Adium Updates\n")
+	assert.Contains(t, stdout, "Language: en\n")
+	assert.Contains(t, stdout, "Releases: 5 total\n")
+	assert.Contains(t, stdout, "VERSION   BUILD     PUBLISHED   PRE-RELEASE  DOWNLOADS\n")
+	assert.Contains(t, stdout, "1.5.10.4  1.5.10.4  2017-05-14  no           1\n")
+
+	// test (remote)
+	status, stdout, stderr = runTest("inspect", "-title", "beta", "https://github.com/atom/atom/releases.atom")
+	assert.Equal(t, exitSuccess, status)
+	assert.Contains(t, stdout, "Provider: GitHub Atom Feed\n")
+	assert.Contains(t, stdout, "Releases: 5 total\n")
+	assert.Contains(t, stdout, "1.28.0-beta3  -      2018-06-06  yes          0\n")
+
+	// test (json)
+	status, stdout, stderr = runTest("inspect", "--json", "-constraint", "~> 1.5", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+
+	var result inspectResult
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "Sparkle RSS Feed", result.Provider)
+	assert.Equal(t, "Sparkle RSS Feed", result.Candidates[0].Provider)
+	assert.Equal(t, 1.0, result.Candidates[0].Confidence)
+	assert.Contains(t, result.Candidates[0].Reasons, "declares the Sparkle namespace")
+	assert.Equal(t, "Adium Updates", result.Channel.Title)
+	assert.Len(t, result.Releases, 2)
+	assert.Equal(t, "1.5.10.4", result.Releases[0].Version)
+	assert.Equal(t, "https://adiumx.cachefly.net/Adium_1.5.10.4.dmg", result.Releases[0].Downloads[0].Url)
+
+	// test (error)
+	status, stdout, stderr = runTest("inspect", testdataPath("unknown.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "appcast: releases for the \"Unknown\" provider can't be unmarshaled\n", stderr)
+
+	status, _, stderr = runTest("inspect", "-url", "[", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Contains(t, stderr, "appcast: error parsing regexp")
+}
+
+func TestRunLatest(t *testing.T) {
+	// mock the request
+	content, _ := ioutil.ReadFile(testdataPath("github.xml"))
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", "https://github.com/atom/atom/releases.atom", httpmock.NewBytesResponder(200, content))
+	defer httpmock.DeactivateAndReset()
+
+	// test (local)
+	status, stdout, stderr := runTest("latest", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "1.5.10.4\thttps://adiumx.cachefly.net/Adium_1.5.10.4.dmg\n", stdout)
+
+	// test (stable only)
+	status, stdout, _ = runTest("latest", "https://github.com/atom/atom/releases.atom")
+	assert.Equal(t, exitSuccess, status)
+	assert.Equal(t, "1.27.2\t\n", stdout)
+
+	// test (prerelease)
+	status, stdout, _ = runTest("latest", "-prerelease", "https://github.com/atom/atom/releases.atom")
+	assert.Equal(t, exitSuccess, status)
+	assert.Equal(t, "1.28.0-beta3\t\n", stdout)
+
+	// test (json)
+	status, stdout, _ = runTest("latest", "-json", "-constraint", "< 1.5", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+
+	var result releaseResult
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "1.4.5", result.Version)
+	assert.Equal(t, "Adium 1.4.5", result.Title)
+	assert.Equal(t, "2012-03-20T15:30:00-05:00", result.PublishedDateTime.Format("2006-01-02T15:04:05Z07:00"))
+	assert.False(t, result.IsPreRelease)
+
+	// test (error)
+	status, stdout, stderr = runTest("latest", "-constraint", "> 2.0", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "appcast: no releases found\n", stderr)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/output"
+	"github.com/victorpopkov/go-appcast/provider/github"
+	"github.com/victorpopkov/go-appcast/provider/jsonfeed"
+	"github.com/victorpopkov/go-appcast/provider/sourceforge"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+)
+
+// marshaller is the interface that wraps the provider-specific appcast
+// marshalling methods.
+type marshaller interface {
+	appcaster.Appcaster
+	Marshal() ([]byte, error)
+}
+
+// targets holds the supported conversion targets. Each target creates a new
+// provider-specific appcast from the provided channel information.
+var targets = map[string]func(c *channelResult) marshaller{
+	"github": func(c *channelResult) marshaller {
+		return github.New()
+	},
+	"jsonfeed": func(c *channelResult) marshaller {
+		a := jsonfeed.New()
+		if c != nil {
+			a.SetFeed(&jsonfeed.Feed{
+				Title:       c.Title,
+				HomePageUrl: c.Link,
+				Description: c.Description,
+				Language:    c.Language,
+			})
+		}
+
+		return a
+	},
+	"sourceforge": func(c *channelResult) marshaller {
+		return sourceforge.New()
+	},
+	"sparkle": func(c *channelResult) marshaller {
+		a := sparkle.New()
+		if c != nil {
+			a.SetChannel(&sparkle.Channel{
+				Title:       c.Title,
+				Link:        c.Link,
+				Description: c.Description,
+				Language:    c.Language,
+			})
+		}
+
+		return a
+	},
+}
+
+// targetNames returns the sorted names of the supported conversion targets.
+func targetNames() []string {
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// runConvert runs the "convert" command which re-emits the appcast as another
+// provider using its marshaller. The result is printed or saved to the output
+// file.
+func runConvert(args []string, stdout io.Writer, stderr io.Writer) int {
+	o := new(options)
+	fs := newFlagSet("convert", stderr, o)
+
+	to := fs.String("to", "", "convert to the `provider`: "+strings.Join(targetNames(), ", "))
+	out := fs.String("o", "", "save the result to the `file` instead of printing it")
+
+	target, status := parse(fs, args, stderr)
+	if status >= 0 {
+		return status
+	}
+
+	create, ok := targets[*to]
+	if !ok {
+		fmt.Fprintf(stderr, "appcast: unsupported target provider %q, expected one of: %s\n", *to, strings.Join(targetNames(), ", "))
+		return exitUsage
+	}
+
+	a, p, errs, err := load(target, o)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if err = filter(a.Releases(), o); err != nil {
+		return fail(stderr, err)
+	}
+
+	warn(stderr, errs)
+
+	m := create(newChannelResult(p))
+	m.SetReleases(a.Releases())
+
+	if *out != "" {
+		m.SetOutput(output.NewLocal(*out, 0644))
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if *out == "" {
+		stdout.Write(content)
+		return exitSuccess
+	}
+
+	if err = m.Output().Save(); err != nil {
+		return fail(stderr, err)
+	}
+
+	return exitSuccess
+}
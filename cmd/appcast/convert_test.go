@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+)
+
+func TestTargetNames(t *testing.T) {
+	assert.Equal(t, []string{"github", "jsonfeed", "sourceforge", "sparkle"}, targetNames())
+}
+
+func TestRunConvert(t *testing.T) {
+	testCases := map[string]provider.Provider{
+		"github":      provider.GitHub,
+		"jsonfeed":    provider.JSONFeed,
+		"sourceforge": provider.SourceForge,
+		"sparkle":     provider.Sparkle,
+	}
+
+	// test (successful)
+	for to, expected := range testCases {
+		status, stdout, stderr := runTest("convert", "-to", to, testdataPath("sparkle.xml"))
+		assert.Equal(t, exitSuccess, status, to)
+		assert.Empty(t, stderr, to)
+		assert.Equal(t, expected, provider.GuessProviderByContentString(stdout), to)
+	}
+
+	// test (successful) [channel]
+	_, stdout, _ := runTest("convert", "-to", "sparkle", "-constraint", "~> 1.5", testdataPath("sparkle.xml"))
+
+	src := new(appcaster.Source)
+	src.SetContent([]byte(stdout))
+
+	a := sparkle.New(src)
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 2, a.Releases().Len())
+	assert.Equal(t, "Adium Updates", a.Channel().Title)
+	assert.Equal(t, "https://adiumx.cachefly.net/Adium_1.5.10.4.dmg", a.Releases().First().Downloads()[0].Url())
+
+	// test (successful) [output]
+	dir, _ := ioutil.TempDir("", "appcast")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "feed.json")
+	status, stdout, stderr := runTest("convert", "-to", "jsonfeed", "-o", path, testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+	assert.Empty(t, stdout)
+	assert.Empty(t, stderr)
+
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"title": "Adium Updates"`)
+
+	// test (error)
+	status, _, stderr = runTest("convert", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitUsage, status)
+	assert.Equal(t, "appcast: unsupported target provider \"\", expected one of: github, jsonfeed, sourceforge, sparkle\n", stderr)
+
+	status, _, stderr = runTest("convert", "-to", "sparkle", testdataPath("unknown.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Equal(t, "appcast: releases for the \"Unknown\" provider can't be unmarshaled\n", stderr)
+
+	status, _, stderr = runTest("convert", "-to", "sparkle", "-o", filepath.Join(dir, "missing", "feed.xml"), testdataPath("sparkle.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Contains(t, stderr, "no such file or directory")
+}
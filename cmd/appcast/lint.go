@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/victorpopkov/go-appcast/lint"
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// lintResult represents the "lint" command report.
+type lintResult struct {
+	Source   string        `json:"source"`
+	Provider string        `json:"provider"`
+	Issues   []issueResult `json:"issues"`
+}
+
+// issueResult represents a single lint issue.
+type issueResult struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Item     int    `json:"item,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix,omitempty"`
+}
+
+// runLint runs the "lint" command which checks the appcast against the lint
+// rules and reports the found issues with their positions and suggested fixes.
+// The releases filters are ignored, as the whole feed is checked. Exits with
+// the failure status, if at least one issue with the error severity is found.
+func runLint(args []string, stdout io.Writer, stderr io.Writer) int {
+	o := new(options)
+	fs := newFlagSet("lint", stderr, o)
+
+	remote := fs.Bool("remote", false, "run the remote rules, for example, compare the download lengths using the HEAD requests")
+	min := fs.String("severity", lint.Info.String(), "report only the issues with at least the provided `severity`: info, warning, error")
+	disable := fs.String("disable", "", "disable the comma-separated `rules`")
+
+	target, status := parse(fs, args, stderr)
+	if status >= 0 {
+		return status
+	}
+
+	minSeverity, err := lint.ParseSeverity(*min)
+	if err != nil {
+		fmt.Fprintf(stderr, "appcast: %s\n", err.Error())
+		return exitUsage
+	}
+
+	l := lint.New()
+
+	if *disable != "" {
+		for _, name := range strings.Split(*disable, ",") {
+			if err := l.Disable(strings.TrimSpace(name)); err != nil {
+				fmt.Fprintf(stderr, "appcast: %s\n", err.Error())
+				return exitUsage
+			}
+		}
+	}
+
+	if *remote {
+		l.SetClient(source.DefaultClient)
+	}
+
+	a, p, errs, err := load(target, o)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	result := lintResult{
+		Source:   target,
+		Provider: a.Source().Provider().String(),
+		Issues:   []issueResult{},
+	}
+
+	failed := false
+
+	for _, i := range l.Lint(p, errs...) {
+		if i.Severity < minSeverity {
+			continue
+		}
+
+		if i.Severity == lint.Error {
+			failed = true
+		}
+
+		result.Issues = append(result.Issues, issueResult{
+			Rule:     i.Rule,
+			Severity: i.Severity.String(),
+			Item:     i.Position.Item,
+			Line:     i.Position.Line,
+			Message:  i.Message,
+			Fix:      i.Fix,
+		})
+
+		if !o.json {
+			fmt.Fprintln(stdout, i)
+			if i.Fix != "" {
+				fmt.Fprintf(stdout, "  fix: %s\n", i.Fix)
+			}
+		}
+	}
+
+	if o.json {
+		if status := printJSON(stdout, stderr, result); status != exitSuccess {
+			return status
+		}
+	} else {
+		fmt.Fprintf(stdout, "%s: %d issue(s) found\n", target, len(result.Issues))
+	}
+
+	if failed {
+		return exitFailure
+	}
+
+	return exitSuccess
+}
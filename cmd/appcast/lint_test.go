@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lintTestdataPath returns a full path for the provided lint testdata file.
+func lintTestdataPath(name string) string {
+	return filepath.Join(testdataPath(), "../lint/testdata/", name)
+}
+
+func TestRunLint(t *testing.T) {
+	// test (valid)
+	path := lintTestdataPath("valid.xml")
+	status, stdout, stderr := runTest("lint", path)
+	assert.Equal(t, exitSuccess, status)
+	assert.Empty(t, stderr)
+	assert.Equal(t, path+": 0 issue(s) found\n", stdout)
+
+	// test (issues)
+	path = lintTestdataPath("issues.xml")
+	status, stdout, _ = runTest("lint", "-severity", "error", path)
+	assert.Equal(t, exitFailure, status)
+	assert.Equal(t, "line 18, item #3: error [duplicate-version]: release 1.1.0 (110) is duplicated\n"+
+		"  fix: Remove the duplicate release or bump its version\n"+
+		"line 23, item #4: error [invalid-release]: malformed version: invalid\n"+
+		"  fix: Use the semantic version, for example: 1.2.3\n"+
+		path+": 2 issue(s) found\n", stdout)
+
+	status, stdout, _ = runTest("lint", "-severity", "error", "-disable", "duplicate-version, invalid-release", path)
+	assert.Equal(t, exitSuccess, status)
+	assert.Equal(t, path+": 0 issue(s) found\n", stdout)
+
+	// test (issues) [json]
+	status, stdout, _ = runTest("lint", "-json", "-disable", "missing-signature", path)
+	assert.Equal(t, exitFailure, status)
+
+	var result lintResult
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "Sparkle RSS Feed", result.Provider)
+	assert.Len(t, result.Issues, 7)
+	assert.Equal(t, issueResult{
+		Rule:     "insecure-url",
+		Severity: "warning",
+		Item:     2,
+		Line:     13,
+		Message:  "download http://example.com/app_1.1.0.dmg is served over HTTP",
+		Fix:      "Use the HTTPS URL: https://example.com/app_1.1.0.dmg",
+	}, result.Issues[2])
+
+	// test (usage)
+	status, _, stderr = runTest("lint", "-severity", "fatal", path)
+	assert.Equal(t, exitUsage, status)
+	assert.Equal(t, "appcast: unknown severity: fatal\n", stderr)
+
+	status, _, stderr = runTest("lint", "-disable", "unknown", path)
+	assert.Equal(t, exitUsage, status)
+	assert.Equal(t, "appcast: unknown rule: unknown\n", stderr)
+
+	// test (error)
+	status, _, stderr = runTest("lint", testdataPath("unknown.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.NotEmpty(t, stderr)
+}
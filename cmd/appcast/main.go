@@ -0,0 +1,247 @@
+// Command appcast inspects the appcasts from the command line.
+//
+// Usage:
+//
+//	appcast <command> [flags] <url|file>
+//
+// The commands are:
+//
+//	convert   re-emit the appcast as another provider
+//	inspect   print the detected provider, channel information and releases
+//	latest    print the newest release version and download URL
+//	lint      report the issues with their severities and suggested fixes
+//	validate  report the unparseable versions, bad dates and missing downloads
+//
+// All commands support the releases filters: "-title", "-media-type", "-url"
+// and "-constraint", which are ignored by the "lint" command as it checks the
+// whole feed. The "-lenient" flag repairs the malformed XML feeds instead of
+// failing. Except the "convert" command, they also support the "-json" flag
+// to print the JSON output instead. Run "appcast <command> -h" to
+// see all the command flags.
+//
+// The appcast is read from the standard input, if the "-" argument is used
+// instead of the url or file.
+//
+// The "validate" command exits with status 1, if at least one problem is found,
+// so it can be used as a pre-publish gate in the release pipelines. The "lint"
+// command does the same, if at least one issue with the error severity is
+// found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/victorpopkov/go-appcast"
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// Exit statuses.
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// command represents a single subcommand.
+type command struct {
+	// name specifies the command name.
+	name string
+
+	// description specifies the short command description shown in usage.
+	description string
+
+	// run runs the command with the provided arguments and returns the exit
+	// status.
+	run func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+// commands holds all the supported subcommands.
+var commands = []command{
+	{"convert", "re-emit the appcast as another provider", runConvert},
+	{"inspect", "print the detected provider, channel information and releases", runInspect},
+	{"latest", "print the newest release version and download URL", runLatest},
+	{"lint", "report the issues with their severities and suggested fixes", runLint},
+	{"validate", "report the unparseable versions, bad dates and missing downloads", runValidate},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the subcommand from the provided arguments and returns the exit
+// status.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		usage(stdout)
+		return exitSuccess
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "appcast: unknown command %q\n\n", args[0])
+	usage(stderr)
+
+	return exitUsage
+}
+
+// usage prints the general usage into the provided writer.
+func usage(w io.Writer) {
+	fmt.Fprint(w, "Usage: appcast <command> [flags] <url|file>\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.description)
+	}
+}
+
+// options represents the flags shared by all the commands.
+type options struct {
+	json       bool
+	lenient    bool
+	title      string
+	mediaType  string
+	url        string
+	constraint string
+}
+
+// newFlagSet returns a new flag.FlagSet instance pointer for the provided
+// command with the shared flags bound to the provided options.
+func newFlagSet(name string, stderr io.Writer, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: appcast %s [flags] <url|file>\n\nFlags:\n", name)
+		fs.PrintDefaults()
+	}
+
+	fs.BoolVar(&o.json, "json", false, "print the JSON output")
+	fs.BoolVar(&o.lenient, "lenient", false, "repair the malformed XML instead of failing and report each repair as a warning")
+	fs.StringVar(&o.title, "title", "", "keep only the releases with titles matching the `regexp`")
+	fs.StringVar(&o.mediaType, "media-type", "", "keep only the releases with download media types matching the `regexp`")
+	fs.StringVar(&o.url, "url", "", "keep only the releases with download URLs matching the `regexp`")
+	fs.StringVar(&o.constraint, "constraint", "", "keep only the releases matching the version `constraint`, for example: \">= 1.2, < 2.0\"")
+
+	return fs
+}
+
+// parse parses the provided arguments using the provided flag.FlagSet and
+// returns the single url or file argument alongside with the exit status. The
+// usage is printed when the arguments are invalid or the help is requested.
+func parse(fs *flag.FlagSet, args []string, stderr io.Writer) (string, int) {
+	if err := fs.Parse(args); err == flag.ErrHelp {
+		return "", exitSuccess
+	} else if err != nil {
+		return "", exitUsage
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintf(stderr, "appcast: expected exactly one url or file, got %d\n\n", fs.NArg())
+		fs.Usage()
+		return "", exitUsage
+	}
+
+	return fs.Arg(0), -1
+}
+
+// load loads the appcast from the provided remote URL or local file path. The
+// arguments starting with "http://" or "https://" are considered to be remote
+// and the "-" argument reads the appcast from the standard input.
+//
+// The malformed XML is repaired, if the lenient option is set. It returns the
+// loaded appcast, the provider-specific appcast and the non-fatal
+// unmarshalling errors. Returns an error, if the appcast can't be loaded or
+// unmarshalled at all.
+func load(target string, o *options) (*appcast.Appcast, appcaster.Appcaster, []error, error) {
+	var p appcaster.Appcaster
+	var errs []error
+
+	a := appcast.New()
+	a.SetLenient(o.lenient)
+
+	switch {
+	case isRemote(target):
+		p, errs = a.LoadFromRemoteSource(target)
+	case target == "-":
+		a.SetSource(source.NewStdin())
+		if err := a.LoadSource(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		p, errs = a.Unmarshal()
+	default:
+		p, errs = a.LoadFromLocalSource(target)
+	}
+
+	if p == nil {
+		if len(errs) > 0 {
+			return nil, nil, nil, errs[0]
+		}
+
+		return nil, nil, nil, fmt.Errorf("no appcast")
+	}
+
+	return a, p, errs, nil
+}
+
+// isRemote checks whether the provided target is a remote URL.
+func isRemote(target string) bool {
+	lower := strings.ToLower(target)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// filter applies the provided options filters to the provided releases.
+// Returns an error, if any filter is malformed.
+func filter(releases release.Releaseser, o *options) error {
+	for _, re := range []string{o.title, o.mediaType, o.url} {
+		if _, err := regexp.Compile(re); err != nil {
+			return err
+		}
+	}
+
+	if o.title != "" {
+		releases.FilterByTitle(o.title)
+	}
+
+	if o.mediaType != "" {
+		releases.FilterByMediaType(o.mediaType)
+	}
+
+	if o.url != "" {
+		releases.FilterByUrl(o.url)
+	}
+
+	if o.constraint != "" {
+		return releases.FilterByVersionConstraint(o.constraint)
+	}
+
+	return nil
+}
+
+// fail prints the provided error into the provided writer and returns the
+// failure exit status.
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, "appcast: %s\n", err.Error())
+	return exitFailure
+}
+
+// warn prints the provided non-fatal errors into the provided writer.
+func warn(stderr io.Writer, errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(stderr, "appcast: warning: %s\n", err.Error())
+	}
+}
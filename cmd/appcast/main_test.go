@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// testdataPath returns a full path for the provided testdata file from the
+// repository root testdata directory. If the current working directory is not
+// available prints an error to os.Stdout and exits with error status 1.
+func testdataPath(paths ...string) string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	return filepath.Join(pwd, "../../testdata/", filepath.Join(paths...))
+}
+
+// runTest runs the command with the provided arguments and returns the exit
+// status alongside with the stdout and stderr outputs.
+func runTest(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, &stdout, &stderr)
+
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	// test (no command)
+	status, stdout, stderr := runTest()
+	assert.Equal(t, exitUsage, status)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Usage: appcast <command> [flags] <url|file>")
+
+	// test (help)
+	status, stdout, stderr = runTest("help")
+	assert.Equal(t, exitSuccess, status)
+	assert.Contains(t, stdout, "inspect   print the detected provider")
+	assert.Empty(t, stderr)
+
+	// test (unknown command)
+	status, stdout, stderr = runTest("invalid")
+	assert.Equal(t, exitUsage, status)
+	assert.Contains(t, stderr, "appcast: unknown command \"invalid\"")
+
+	// test (command help)
+	status, _, stderr = runTest("inspect", "-h")
+	assert.Equal(t, exitSuccess, status)
+	assert.Contains(t, stderr, "Usage: appcast inspect [flags] <url|file>")
+
+	// test (invalid flag)
+	status, _, stderr = runTest("inspect", "-invalid", "file.xml")
+	assert.Equal(t, exitUsage, status)
+	assert.Contains(t, stderr, "flag provided but not defined: -invalid")
+
+	// test (missing argument)
+	status, _, stderr = runTest("latest")
+	assert.Equal(t, exitUsage, status)
+	assert.Contains(t, stderr, "appcast: expected exactly one url or file, got 0")
+}
+
+func TestLoad(t *testing.T) {
+	// mock the request
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", "https://example.com/appcast.xml", httpmock.NewBytesResponder(200, []byte("invalid")))
+	defer httpmock.DeactivateAndReset()
+
+	// test (local)
+	a, p, errs, err := load(testdataPath("sparkle.xml"), new(options))
+	assert.Nil(t, err)
+	assert.Len(t, errs, 0)
+	assert.NotNil(t, p)
+	assert.Equal(t, 5, a.Releases().Len())
+
+	// test (local error)
+	a, p, errs, err = load(testdataPath("invalid.xml"), new(options))
+	assert.Nil(t, a)
+	assert.Nil(t, p)
+	assert.Error(t, err)
+
+	// test (remote error)
+	a, p, errs, err = load("https://example.com/appcast.xml", new(options))
+	assert.Nil(t, a)
+	assert.EqualError(t, err, "releases for the \"Unknown\" provider can't be unmarshaled")
+
+	// test (stdin)
+	content, _ := ioutil.ReadFile(testdataPath("sparkle.xml"))
+	stdin := source.Stdin
+	source.Stdin = bytes.NewReader(content)
+	defer func() { source.Stdin = stdin }()
+
+	a, p, errs, err = load("-", new(options))
+	assert.Nil(t, err)
+	assert.Len(t, errs, 0)
+	assert.NotNil(t, p)
+	assert.Equal(t, 5, a.Releases().Len())
+}
+
+func TestIsRemote(t *testing.T) {
+	testCases := map[string]bool{
+		"http://example.com/appcast.xml":  true,
+		"HTTPS://example.com/appcast.xml": true,
+		"appcast.xml":                     false,
+		"/tmp/http/appcast.xml":           false,
+	}
+
+	for target, expected := range testCases {
+		assert.Equal(t, expected, isRemote(target), target)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	// preparations
+	a, _, _, _ := load(testdataPath("sparkle.xml"), new(options))
+
+	// test (successful)
+	err := filter(a.Releases(), &options{title: "1\\.5", url: "dmg", constraint: ">= 1.5.10.4"})
+	assert.Nil(t, err)
+	assert.Len(t, a.Releases().Filtered(), 1)
+
+	// test (error)
+	assert.EqualError(t, filter(a.Releases(), &options{mediaType: "["}), "error parsing regexp: missing closing ]: `[`")
+	assert.EqualError(t, filter(a.Releases(), &options{constraint: "invalid"}), "malformed constraint: invalid")
+}
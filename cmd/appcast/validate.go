@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// Problem codes reported by the "validate" command.
+const (
+	codeUnreadable       = "unreadable"
+	codeInvalidFeed      = "invalid_feed"
+	codeMalformedXML     = "malformed_xml"
+	codeNoVersion        = "no_version"
+	codeMalformedVersion = "malformed_version"
+	codeMalformedDate    = "malformed_date"
+	codeInvalidRelease   = "invalid_release"
+	codeMissingDownload  = "missing_download"
+)
+
+// validateResult represents the "validate" command report.
+type validateResult struct {
+	Source   string    `json:"source"`
+	Provider string    `json:"provider,omitempty"`
+	Valid    bool      `json:"valid"`
+	Releases int       `json:"releases"`
+	Problems []problem `json:"problems"`
+}
+
+// problem represents a single validation problem.
+type problem struct {
+	// Code specifies the machine-readable problem code.
+	Code string `json:"code"`
+
+	// Release specifies the 1-based release position in the feed. It's 0, if
+	// the problem isn't related to the release or its position is unknown.
+	Release int `json:"release,omitempty"`
+
+	// Version specifies the release version or build, if known.
+	Version string `json:"version,omitempty"`
+
+	// Field specifies the failed release field, if known.
+	Field string `json:"field,omitempty"`
+
+	// Value specifies the raw value of the failed release field.
+	Value string `json:"value,omitempty"`
+
+	// Line specifies the 1-based XML line of the release. It's 0, if unknown.
+	Line int `json:"line,omitempty"`
+
+	// Message specifies the human-readable problem description.
+	Message string `json:"message"`
+}
+
+// String returns the string representation of the problem.
+func (p problem) String() string {
+	var subject string
+
+	switch {
+	case p.Release > 0:
+		subject = fmt.Sprintf("release #%d: ", p.Release)
+	case p.Version != "":
+		subject = fmt.Sprintf("release %s: ", p.Version)
+	case p.Line > 0:
+		subject = fmt.Sprintf("line %d: ", p.Line)
+	}
+
+	return fmt.Sprintf("%s%s [%s]", subject, p.Message, p.Code)
+}
+
+// runValidate runs the "validate" command which reports the unparseable
+// versions, malformed dates and missing downloads. Exits with the failure
+// status, if at least one problem is found.
+func runValidate(args []string, stdout io.Writer, stderr io.Writer) int {
+	o := new(options)
+	fs := newFlagSet("validate", stderr, o)
+
+	target, status := parse(fs, args, stderr)
+	if status >= 0 {
+		return status
+	}
+
+	result := validateResult{Source: target, Problems: []problem{}}
+
+	a, _, errs, err := load(target, o)
+	if err != nil {
+		result.Problems = append(result.Problems, problem{Code: codeUnreadable, Message: err.Error()})
+	} else {
+		if err = filter(a.Releases(), o); err != nil {
+			return fail(stderr, err)
+		}
+
+		result.Provider = a.Source().Provider().String()
+		result.Releases = len(a.Releases().Filtered())
+		result.Problems = append(result.Problems, newProblems(errs)...)
+
+		if expectsDownloads(a.Source().Provider()) {
+			result.Problems = append(result.Problems, missingDownloads(a.Releases())...)
+		}
+	}
+
+	result.Valid = len(result.Problems) == 0
+
+	if o.json {
+		if status := printJSON(stdout, stderr, result); status != exitSuccess {
+			return status
+		}
+	} else {
+		for _, p := range result.Problems {
+			fmt.Fprintln(stdout, p)
+		}
+
+		if result.Valid {
+			fmt.Fprintf(stdout, "%s: valid (%d releases)\n", target, result.Releases)
+		} else {
+			fmt.Fprintf(stdout, "%s: %d problem(s) found\n", target, len(result.Problems))
+		}
+	}
+
+	if !result.Valid {
+		return exitFailure
+	}
+
+	return exitSuccess
+}
+
+// newProblems returns the problems created from the provided unmarshalling
+// errors.
+func newProblems(errs []error) []problem {
+	var problems []problem
+
+	for _, err := range errs {
+		var r *appcaster.Repair
+		if errors.As(err, &r) {
+			problems = append(problems, problem{Code: codeMalformedXML, Line: r.Line, Message: r.Message})
+			continue
+		}
+
+		var re *appcaster.ReleaseError
+		if !errors.As(err, &re) {
+			problems = append(problems, problem{Code: codeInvalidFeed, Message: err.Error()})
+			continue
+		}
+
+		problems = append(problems, problem{
+			Code:    problemCode(re),
+			Release: re.Index,
+			Field:   re.Field,
+			Value:   re.Value,
+			Line:    re.Line,
+			Message: re.Err.Error(),
+		})
+	}
+
+	return problems
+}
+
+// problemCode returns the problem code for the provided release error.
+func problemCode(re *appcaster.ReleaseError) string {
+	switch {
+	case errors.Is(re.Err, appcaster.ErrNoVersion):
+		return codeNoVersion
+	case re.Field == "version":
+		return codeMalformedVersion
+	case re.Field == "publishedDateTime":
+		return codeMalformedDate
+	}
+
+	return codeInvalidRelease
+}
+
+// expectsDownloads checks whether the releases of the provided provider are
+// expected to have downloads. The GitHub releases Atom feed doesn't include
+// the release assets at all.
+func expectsDownloads(p appcaster.Providerer) bool {
+	return p != provider.GitHub
+}
+
+// missingDownloads returns the problems for the filtered releases without any
+// download URL.
+func missingDownloads(releases release.Releaseser) []problem {
+	var problems []problem
+
+	for _, r := range releases.Filtered() {
+		found := false
+		for _, d := range r.Downloads() {
+			if d.Url() != "" {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			problems = append(problems, problem{
+				Code:    codeMissingDownload,
+				Version: r.VersionOrBuildString(),
+				Message: "no download",
+			})
+		}
+	}
+
+	return problems
+}
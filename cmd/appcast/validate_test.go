@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// sparkleTestdataPath returns a full path for the provided Sparkle provider
+// unmarshal testdata file.
+func sparkleTestdataPath(name string) string {
+	return filepath.Join(testdataPath(), "../provider/sparkle/testdata/unmarshal/", name)
+}
+
+func TestRunValidate(t *testing.T) {
+	// test (valid)
+	status, stdout, stderr := runTest("validate", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitSuccess, status)
+	assert.Empty(t, stderr)
+	assert.Equal(t, testdataPath("sparkle.xml")+": valid (5 releases)\n", stdout)
+
+	status, stdout, _ = runTest("validate", testdataPath("github.xml"))
+	assert.Equal(t, exitSuccess, status)
+
+	// test (invalid)
+	path := sparkleTestdataPath("invalid_version.xml")
+	status, stdout, _ = runTest("validate", path)
+	assert.Equal(t, exitFailure, status)
+	assert.Equal(t, "release #2: malformed version: invalid [malformed_version]\n"+path+": 1 problem(s) found\n", stdout)
+
+	// test (invalid) [json]
+	status, stdout, _ = runTest("validate", "-json", sparkleTestdataPath("invalid_pubdate.xml"))
+	assert.Equal(t, exitFailure, status)
+
+	var result validateResult
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, "Sparkle RSS Feed", result.Provider)
+	assert.Equal(t, 4, result.Releases)
+	assert.Equal(t, []problem{{
+		Code:    codeMalformedDate,
+		Release: 2,
+		Field:   "publishedDateTime",
+		Value:   "invalid",
+		Line:    15,
+		Message: "parsing of the published datetime failed",
+	}}, result.Problems)
+
+	// test (lenient)
+	path = sparkleTestdataPath("malformed.xml")
+	status, stdout, _ = runTest("validate", "-lenient", path)
+	assert.Equal(t, exitFailure, status)
+	assert.Equal(t, "line 4: unescaped \"&\" escaped [malformed_xml]\n"+
+		"line 10: unbalanced markup of the \"description\" element wrapped in CDATA [malformed_xml]\n"+
+		"line 13: unescaped \"&\" escaped [malformed_xml]\n"+
+		path+": 3 problem(s) found\n", stdout)
+
+	// test (unreadable)
+	status, stdout, _ = runTest("validate", "-json", testdataPath("unknown.xml"))
+	assert.Equal(t, exitFailure, status)
+
+	result = validateResult{}
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, codeUnreadable, result.Problems[0].Code)
+
+	// test (error)
+	status, _, stderr = runTest("validate", "-constraint", "invalid", testdataPath("sparkle.xml"))
+	assert.Equal(t, exitFailure, status)
+	assert.Equal(t, "appcast: malformed constraint: invalid\n", stderr)
+}
+
+func TestNewProblems(t *testing.T) {
+	// test
+	problems := newProblems([]error{
+		appcaster.NewReleaseError(0, "version", "", appcaster.ErrNoVersion),
+		appcaster.NewReleaseError(1, "version", "invalid", errors.New("malformed version: invalid")),
+		appcaster.NewReleaseError(2, "publishedDateTime", "invalid", errors.New("parsing of the published datetime failed")),
+		appcaster.NewReleaseError(3, "title", "", errors.New("unknown")),
+		errors.New("EOF"),
+		&appcaster.Repair{Line: 5, Column: 10, Message: "unescaped \"&\" escaped"},
+	})
+
+	assert.Equal(t, []problem{
+		{Code: codeNoVersion, Release: 1, Field: "version", Message: "no version"},
+		{Code: codeMalformedVersion, Release: 2, Field: "version", Value: "invalid", Message: "malformed version: invalid"},
+		{Code: codeMalformedDate, Release: 3, Field: "publishedDateTime", Value: "invalid", Message: "parsing of the published datetime failed"},
+		{Code: codeInvalidRelease, Release: 4, Field: "title", Message: "unknown"},
+		{Code: codeInvalidFeed, Message: "EOF"},
+		{Code: codeMalformedXML, Line: 5, Message: "unescaped \"&\" escaped"},
+	}, problems)
+
+	// test (empty)
+	assert.Nil(t, newProblems(nil))
+
+	// test (wrapped)
+	problems = newProblems([]error{
+		fmt.Errorf("feed: %w", appcaster.NewReleaseError(0, "version", "", appcaster.ErrNoVersion)),
+		fmt.Errorf("feed: %w", &appcaster.Repair{Line: 5, Message: "unescaped \"&\" escaped"}),
+	})
+
+	assert.Equal(t, []problem{
+		{Code: codeNoVersion, Release: 1, Field: "version", Message: "no version"},
+		{Code: codeMalformedXML, Line: 5, Message: "unescaped \"&\" escaped"},
+	}, problems)
+}
+
+func TestMissingDownloads(t *testing.T) {
+	// preparations
+	r1, _ := release.New("2.0.0", "200")
+	r1.AddDownload(*release.NewDownload("https://example.com/app_2.0.0.dmg"))
+
+	r2, _ := release.New("1.0.0", "100")
+	r2.AddDownload(*release.NewDownload(""))
+
+	// test
+	problems := missingDownloads(release.NewReleases([]release.Releaser{r1, r2}))
+	assert.Equal(t, []problem{{Code: codeMissingDownload, Version: "1.0.0", Message: "no download"}}, problems)
+	assert.Equal(t, "release 1.0.0: no download [missing_download]", problems[0].String())
+}
@@ -0,0 +1,413 @@
+// Package diff compares two appcasts (or their releases) and reports the
+// added, removed and modified releases alongside with their field-level
+// changes.
+//
+// It's useful for monitoring the vendor appcasts: a changed download URL or
+// signature of an already published release may indicate a compromised
+// distribution.
+package diff
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// ChangeType holds different supported release change types.
+type ChangeType int
+
+const (
+	// Added represents a release that exists only in the new releases.
+	Added ChangeType = iota
+
+	// Removed represents a release that exists only in the old releases.
+	Removed
+
+	// Modified represents a release that exists in both releases, but has at
+	// least one changed field.
+	Modified
+)
+
+var changeTypeNames = [...]string{
+	"added",
+	"removed",
+	"modified",
+}
+
+// String returns the string representation of the ChangeType.
+func (t ChangeType) String() string {
+	return changeTypeNames[t]
+}
+
+// sensitiveFields holds the download fields which changes on an existing
+// release are considered to be suspicious.
+var sensitiveFields = map[string]bool{
+	"url":          true,
+	"dsaSignature": true,
+	"edSignature":  true,
+	"md5":          true,
+	"sha256":       true,
+	"length":       true,
+}
+
+// FieldChange represents a single changed release field.
+type FieldChange struct {
+	// Field specifies the changed field name. The download fields are
+	// prefixed with the index of the new download, for example:
+	// "downloads[0].url". The added and removed downloads are reported as a
+	// whole, for example: "downloads[1]".
+	Field string
+
+	// Old specifies the old field value.
+	Old string
+
+	// New specifies the new field value.
+	New string
+
+	// sensitive specifies whether the change is security-sensitive.
+	sensitive bool
+}
+
+// IsSensitive checks whether the FieldChange is security-sensitive: the
+// download URL, length, signature or checksum has been changed or a new
+// download has been added.
+func (c FieldChange) IsSensitive() bool {
+	return c.sensitive
+}
+
+// String returns the string representation of the FieldChange.
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %q => %q", c.Field, c.Old, c.New)
+}
+
+// ReleaseChange represents a single added, removed or modified release.
+type ReleaseChange struct {
+	// Type specifies the change type.
+	Type ChangeType
+
+	// Key specifies the release identity used for matching the releases:
+	// the original version followed by the build in parentheses (if any).
+	Key string
+
+	// Old specifies the old release. It's nil for the added release.
+	Old release.Releaser
+
+	// New specifies the new release. It's nil for the removed release.
+	New release.Releaser
+
+	// Fields specify the changed fields of the modified release.
+	Fields []FieldChange
+}
+
+// IsSuspicious checks whether the ReleaseChange is a modification of the
+// existing release with at least one security-sensitive FieldChange.
+func (c *ReleaseChange) IsSuspicious() bool {
+	if c.Type != Modified {
+		return false
+	}
+
+	for _, f := range c.Fields {
+		if f.IsSensitive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Diff represents the difference between the old and the new releases.
+type Diff struct {
+	// Added specifies the releases that exist only in the new releases.
+	Added []*ReleaseChange
+
+	// Removed specifies the releases that exist only in the old releases.
+	Removed []*ReleaseChange
+
+	// Modified specifies the releases that exist in both, but differ.
+	Modified []*ReleaseChange
+}
+
+// HasChanges checks whether there is at least one added, removed or modified
+// release.
+func (d *Diff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Modified) > 0
+}
+
+// Suspicious returns only the modified releases with security-sensitive
+// changes. See ReleaseChange.IsSuspicious.
+func (d *Diff) Suspicious() (result []*ReleaseChange) {
+	for _, c := range d.Modified {
+		if c.IsSuspicious() {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// Appcasts compares the releases of the provided old and new appcasts. The
+// filtered releases are compared, so the filters can be used to narrow the
+// comparison down.
+func Appcasts(from appcaster.Appcaster, to appcaster.Appcaster) *Diff {
+	return Releases(releasesOf(from), releasesOf(to))
+}
+
+// releasesOf returns the releases of the provided appcast. Returns nil, if
+// the appcast or its releases are nil.
+func releasesOf(a appcaster.Appcaster) release.Releaseser {
+	if a == nil {
+		return nil
+	}
+
+	return a.Releases()
+}
+
+// Releases compares the provided old and new filtered releases. Releases are
+// matched by their keys (see ReleaseChange.Key). The added and modified
+// releases follow the order of the new releases while the removed ones follow
+// the order of the old releases.
+func Releases(from release.Releaseser, to release.Releaseser) *Diff {
+	d := new(Diff)
+
+	oldKeys, oldReleases := index(from)
+	newKeys, newReleases := index(to)
+
+	for _, key := range newKeys {
+		n := newReleases[key]
+		o, ok := oldReleases[key]
+		if !ok {
+			d.Added = append(d.Added, &ReleaseChange{Type: Added, Key: key, New: n})
+			continue
+		}
+
+		fields := compare(o, n)
+		if len(fields) > 0 {
+			d.Modified = append(d.Modified, &ReleaseChange{
+				Type:   Modified,
+				Key:    key,
+				Old:    o,
+				New:    n,
+				Fields: fields,
+			})
+		}
+	}
+
+	for _, key := range oldKeys {
+		if _, ok := newReleases[key]; !ok {
+			d.Removed = append(d.Removed, &ReleaseChange{Type: Removed, Key: key, Old: oldReleases[key]})
+		}
+	}
+
+	return d
+}
+
+// Key returns the release identity used for matching the releases: the
+// original version followed by the build in parentheses (if any).
+func Key(r release.Releaser) string {
+	version := ""
+	if r.Version() != nil {
+		version = r.Version().Original()
+	}
+
+	switch {
+	case version == "":
+		return r.Build()
+	case r.Build() == "" || r.Build() == version:
+		return version
+	}
+
+	return fmt.Sprintf("%s (%s)", version, r.Build())
+}
+
+// index returns the keys of the provided filtered releases in their original
+// order alongside with the releases mapped by these keys. The duplicate keys
+// are suffixed by their occurrence number, for example: "2.0.0 #2".
+func index(releases release.Releaseser) ([]string, map[string]release.Releaser) {
+	var keys []string
+	result := make(map[string]release.Releaser)
+
+	if releases == nil {
+		return keys, result
+	}
+
+	occurrences := make(map[string]int)
+
+	for _, r := range releases.Filtered() {
+		key := Key(r)
+
+		occurrences[key]++
+		if occurrences[key] > 1 {
+			key = fmt.Sprintf("%s #%d", key, occurrences[key])
+		}
+
+		keys = append(keys, key)
+		result[key] = r
+	}
+
+	return keys, result
+}
+
+// compare returns the changed fields between the provided old and new
+// releases.
+func compare(o release.Releaser, n release.Releaser) []FieldChange {
+	var fields []FieldChange
+
+	add := func(field string, before string, after string) {
+		if before != after {
+			fields = append(fields, FieldChange{
+				Field:     field,
+				Old:       before,
+				New:       after,
+				sensitive: sensitiveFields[field],
+			})
+		}
+	}
+
+	add("title", o.Title(), n.Title())
+	add("description", o.Description(), n.Description())
+	add("publishedDateTime", publishedDateTime(o), publishedDateTime(n))
+	add("releaseNotesLink", o.ReleaseNotesLink(), n.ReleaseNotesLink())
+	add("minimumSystemVersion", o.MinimumSystemVersion(), n.MinimumSystemVersion())
+	add("maximumSystemVersion", o.MaximumSystemVersion(), n.MaximumSystemVersion())
+	add("isPreRelease", strconv.FormatBool(o.IsPreRelease()), strconv.FormatBool(n.IsPreRelease()))
+
+	od, nd := o.Downloads(), n.Downloads()
+	if len(od) != len(nd) {
+		fields = append(fields, FieldChange{
+			Field:     "downloads",
+			Old:       strconv.Itoa(len(od)),
+			New:       strconv.Itoa(len(nd)),
+			sensitive: len(nd) > len(od),
+		})
+	}
+
+	matches, added, removed := matchDownloads(od, nd)
+
+	for j := range nd {
+		i, ok := matches[j]
+		if !ok {
+			continue
+		}
+
+		prefix := fmt.Sprintf("downloads[%d].", j)
+		for _, f := range compareDownloads(od[i], nd[j]) {
+			f.Field = prefix + f.Field
+			fields = append(fields, f)
+		}
+	}
+
+	for _, j := range added {
+		fields = append(fields, FieldChange{
+			Field:     fmt.Sprintf("downloads[%d]", j),
+			New:       nd[j].Url(),
+			sensitive: true,
+		})
+	}
+
+	for _, i := range removed {
+		fields = append(fields, FieldChange{
+			Field: fmt.Sprintf("downloads[%d]", i),
+			Old:   od[i].Url(),
+		})
+	}
+
+	return fields
+}
+
+// matchDownloads matches the provided new downloads with the old ones, so the
+// reordered downloads aren't reported as changed. The downloads are matched by
+// their URLs first, then by their filetypes and the remaining ones by their
+// order.
+//
+// Returns the old download index for each matched new download index
+// alongside with the indexes of the added new and removed old downloads.
+func matchDownloads(od []release.Download, nd []release.Download) (matches map[int]int, added []int, removed []int) {
+	matches = make(map[int]int)
+	matched := make(map[int]bool)
+
+	match := func(equal func(o release.Download, n release.Download) bool) {
+		for j := range nd {
+			if _, ok := matches[j]; ok {
+				continue
+			}
+
+			for i := range od {
+				if !matched[i] && equal(od[i], nd[j]) {
+					matches[j], matched[i] = i, true
+					break
+				}
+			}
+		}
+	}
+
+	match(func(o release.Download, n release.Download) bool {
+		return o.Url() == n.Url()
+	})
+
+	match(func(o release.Download, n release.Download) bool {
+		return o.Filetype() != "" && o.Filetype() == n.Filetype()
+	})
+
+	match(func(o release.Download, n release.Download) bool {
+		return true
+	})
+
+	for j := range nd {
+		if _, ok := matches[j]; !ok {
+			added = append(added, j)
+		}
+	}
+
+	for i := range od {
+		if !matched[i] {
+			removed = append(removed, i)
+		}
+	}
+
+	return matches, added, removed
+}
+
+// compareDownloads returns the changed fields between the provided old and
+// new downloads.
+func compareDownloads(o release.Download, n release.Download) []FieldChange {
+	var fields []FieldChange
+
+	add := func(field string, before string, after string) {
+		if before != after {
+			fields = append(fields, FieldChange{
+				Field:     field,
+				Old:       before,
+				New:       after,
+				sensitive: sensitiveFields[field],
+			})
+		}
+	}
+
+	add("url", o.Url(), n.Url())
+	add("filetype", o.Filetype(), n.Filetype())
+	add("length", strconv.Itoa(o.Length()), strconv.Itoa(n.Length()))
+	add("dsaSignature", o.DsaSignature(), n.DsaSignature())
+	add("edSignature", o.EdSignature(), n.EdSignature())
+	add("md5", o.Md5(), n.Md5())
+	add("sha256", o.Sha256(), n.Sha256())
+
+	return fields
+}
+
+// publishedDateTime returns the comparable string representation of the
+// release published datetime. Parsed datetimes are compared in UTC, so the
+// same moment in a different timezone isn't reported as a change.
+func publishedDateTime(r release.Releaser) string {
+	p := r.PublishedDateTime()
+	if p == nil {
+		return ""
+	}
+
+	if p.Time() == nil {
+		return p.String()
+	}
+
+	return p.Time().UTC().String()
+}
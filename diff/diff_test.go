@@ -0,0 +1,232 @@
+package diff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// newTestRelease creates a new release.Release instance for testing purposes
+// with a single download and returns its pointer.
+func newTestRelease(version string, build string) *release.Release {
+	r, err := release.NewLenient(version, build)
+	if r == nil {
+		panic(err)
+	}
+
+	t := time.Date(2016, 5, 13, 12, 0, 0, 0, time.UTC)
+	r.SetTitle("Release " + version)
+	r.SetPublishedDateTime(release.NewPublishedDateTime(&t))
+	r.AddDownload(*release.NewDownload(
+		"https://example.com/app_"+version+".dmg",
+		"application/octet-stream",
+		100000,
+		"MC0CFBfeCa1JyW30nbkBwainOzrN6EQuAh=",
+	))
+
+	return r
+}
+
+// newTestReleases creates a new release.Releases instance for testing
+// purposes holding the provided releases and returns its pointer.
+func newTestReleases(releases ...release.Releaser) *release.Releases {
+	return release.NewReleases(releases)
+}
+
+func TestChangeType_String(t *testing.T) {
+	assert.Equal(t, "added", Added.String())
+	assert.Equal(t, "removed", Removed.String())
+	assert.Equal(t, "modified", Modified.String())
+}
+
+func TestFieldChange_String(t *testing.T) {
+	c := FieldChange{Field: "title", Old: "a", New: "b"}
+	assert.Equal(t, `title: "a" => "b"`, c.String())
+}
+
+func TestReleases(t *testing.T) {
+	// preparations
+	signed := newTestRelease("1.1.0", "110")
+	d := signed.Downloads()[0]
+	d.SetDsaSignature("changed")
+	signed.SetDownloads([]release.Download{d})
+
+	retitled := newTestRelease("1.0.1", "101")
+	retitled.SetTitle("Release 1.0.1 (Hotfix)")
+
+	from := newTestReleases(
+		newTestRelease("1.1.0", "110"),
+		newTestRelease("1.0.1", "101"),
+		newTestRelease("1.0.0", "100"),
+	)
+
+	to := newTestReleases(
+		newTestRelease("2.0.0", "200"),
+		signed,
+		retitled,
+	)
+
+	// test
+	diff := Releases(from, to)
+	assert.True(t, diff.HasChanges())
+
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, Added, diff.Added[0].Type)
+	assert.Equal(t, "2.0.0 (200)", diff.Added[0].Key)
+	assert.Nil(t, diff.Added[0].Old)
+	assert.NotNil(t, diff.Added[0].New)
+
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, Removed, diff.Removed[0].Type)
+	assert.Equal(t, "1.0.0 (100)", diff.Removed[0].Key)
+	assert.NotNil(t, diff.Removed[0].Old)
+	assert.Nil(t, diff.Removed[0].New)
+
+	assert.Len(t, diff.Modified, 2)
+	assert.Equal(t, "1.1.0 (110)", diff.Modified[0].Key)
+	assert.Equal(t, []FieldChange{
+		{Field: "downloads[0].dsaSignature", Old: "MC0CFBfeCa1JyW30nbkBwainOzrN6EQuAh=", New: "changed", sensitive: true},
+	}, diff.Modified[0].Fields)
+	assert.True(t, diff.Modified[0].IsSuspicious())
+
+	assert.Equal(t, "1.0.1 (101)", diff.Modified[1].Key)
+	assert.Equal(t, []FieldChange{
+		{Field: "title", Old: "Release 1.0.1", New: "Release 1.0.1 (Hotfix)"},
+	}, diff.Modified[1].Fields)
+	assert.False(t, diff.Modified[1].IsSuspicious())
+
+	suspicious := diff.Suspicious()
+	assert.Len(t, suspicious, 1)
+	assert.Equal(t, "1.1.0 (110)", suspicious[0].Key)
+
+	// test (without changes)
+	diff = Releases(from, from)
+	assert.False(t, diff.HasChanges())
+
+	// test (nil releases)
+	diff = Releases(nil, from)
+	assert.Len(t, diff.Added, 3)
+	assert.Len(t, diff.Removed, 0)
+}
+
+func TestReleases_PublishedDateTime(t *testing.T) {
+	// preparations
+	r := newTestRelease("1.0.0", "")
+	moved := newTestRelease("1.0.0", "")
+	rewritten := newTestRelease("1.0.0", "")
+
+	// the same moment in a different timezone
+	moment := r.PublishedDateTime().Time().In(time.FixedZone("CEST", 2*60*60))
+	moved.SetPublishedDateTime(release.NewPublishedDateTime(&moment))
+
+	later := r.PublishedDateTime().Time().Add(24 * time.Hour)
+	rewritten.SetPublishedDateTime(release.NewPublishedDateTime(&later))
+
+	// test
+	assert.False(t, Releases(newTestReleases(r), newTestReleases(moved)).HasChanges())
+
+	diff := Releases(newTestReleases(r), newTestReleases(rewritten))
+	assert.Len(t, diff.Modified, 1)
+	assert.Equal(t, "publishedDateTime", diff.Modified[0].Fields[0].Field)
+	assert.False(t, diff.Modified[0].IsSuspicious())
+}
+
+func TestReleases_Downloads(t *testing.T) {
+	// preparations
+	r := newTestRelease("1.0.0", "")
+	changed := newTestRelease("1.0.0", "")
+	d := changed.Downloads()[0]
+	d.SetUrl("https://example.org/app_1.0.0.dmg")
+	d.SetSha256("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	changed.SetDownloads([]release.Download{d, d})
+
+	// test
+	diff := Releases(newTestReleases(r), newTestReleases(changed))
+	assert.Len(t, diff.Modified, 1)
+
+	fields := diff.Modified[0].Fields
+	assert.Len(t, fields, 4)
+	assert.Equal(t, "downloads", fields[0].Field)
+	assert.True(t, fields[0].IsSensitive())
+	assert.Equal(t, "downloads[0].url", fields[1].Field)
+	assert.True(t, fields[1].IsSensitive())
+	assert.Equal(t, "downloads[0].sha256", fields[2].Field)
+	assert.True(t, fields[2].IsSensitive())
+	assert.Equal(t, FieldChange{Field: "downloads[1]", New: "https://example.org/app_1.0.0.dmg", sensitive: true}, fields[3])
+
+	// test (reordered)
+	dmg := *release.NewDownload("https://example.com/app_1.0.0.dmg", "application/x-apple-diskimage")
+	zip := *release.NewDownload("https://example.com/app_1.0.0.zip", "application/zip")
+
+	r.SetDownloads([]release.Download{dmg, zip})
+	changed.SetDownloads([]release.Download{zip, dmg})
+	assert.False(t, Releases(newTestReleases(r), newTestReleases(changed)).HasChanges())
+
+	// test (injected)
+	injected := *release.NewDownload("https://example.org/app_1.0.0.pkg", "application/octet-stream")
+	changed.SetDownloads([]release.Download{zip, injected, dmg})
+
+	diff = Releases(newTestReleases(r), newTestReleases(changed))
+	assert.True(t, diff.Modified[0].IsSuspicious())
+	assert.Equal(t, []FieldChange{
+		{Field: "downloads", Old: "2", New: "3", sensitive: true},
+		{Field: "downloads[1]", New: "https://example.org/app_1.0.0.pkg", sensitive: true},
+	}, diff.Modified[0].Fields)
+
+	// test (removed)
+	changed.SetDownloads([]release.Download{dmg})
+
+	diff = Releases(newTestReleases(r), newTestReleases(changed))
+	assert.False(t, diff.Modified[0].IsSuspicious())
+	assert.Equal(t, []FieldChange{
+		{Field: "downloads", Old: "2", New: "1"},
+		{Field: "downloads[1]", Old: "https://example.com/app_1.0.0.zip"},
+	}, diff.Modified[0].Fields)
+
+	// test (EdDSA signature)
+	signed := dmg
+	signed.SetEdSignature("changed")
+	changed.SetDownloads([]release.Download{zip, signed})
+
+	diff = Releases(newTestReleases(r), newTestReleases(changed))
+	assert.True(t, diff.Modified[0].IsSuspicious())
+	assert.Equal(t, []FieldChange{
+		{Field: "downloads[1].edSignature", New: "changed", sensitive: true},
+	}, diff.Modified[0].Fields)
+}
+
+func TestAppcasts(t *testing.T) {
+	// preparations
+	from := appcaster.New()
+	from.SetReleases(newTestReleases(newTestRelease("1.0.0", "100")))
+
+	to := appcaster.New()
+	to.SetReleases(newTestReleases(newTestRelease("1.0.0", "100"), newTestRelease("1.1.0", "110")))
+
+	// test
+	diff := Appcasts(from, to)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "1.1.0 (110)", diff.Added[0].Key)
+
+	// test (nil appcast)
+	diff = Appcasts(nil, to)
+	assert.Len(t, diff.Added, 2)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "1.0.0", Key(newTestRelease("1.0.0", "")))
+	assert.Equal(t, "1.0.0", Key(newTestRelease("1.0.0", "1.0.0")))
+	assert.Equal(t, "v1.0 (100)", Key(newTestRelease("v1.0", "100")))
+	assert.Equal(t, "100", Key(newTestRelease("invalid", "100")))
+}
+
+func TestIndex(t *testing.T) {
+	// test (duplicates)
+	keys, releases := index(newTestReleases(newTestRelease("1.0.0", ""), newTestRelease("1.0.0", "")))
+	assert.Equal(t, []string{"1.0.0", "1.0.0 #2"}, keys)
+	assert.Len(t, releases, 2)
+}
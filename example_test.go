@@ -7,7 +7,7 @@ import (
 	"path/filepath"
 	"reflect"
 
-	"gopkg.in/jarcoal/httpmock.v1"
+	"github.com/jarcoal/httpmock"
 
 	"github.com/victorpopkov/go-appcast"
 	"github.com/victorpopkov/go-appcast/release"
@@ -0,0 +1,14 @@
+module github.com/victorpopkov/go-appcast
+
+go 1.20
+
+require (
+	github.com/hashicorp/go-version v1.0.0
+	github.com/jarcoal/httpmock v1.0.0
+	github.com/stretchr/testify v1.2.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,170 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// File represents a history storage in the local JSON Lines file. Each line
+// holds a single Record of any feed.
+//
+// The whole file is rewritten on each observation. The new content is written
+// into a temporary file first which then replaces the original one, so the
+// history isn't lost when the writing fails.
+type File struct {
+	mu          sync.Mutex
+	filepath    string
+	permissions os.FileMode
+}
+
+// NewFile returns a new File instance pointer with the File.filepath set. The
+// file is created on the first observation with 0644 permissions.
+func NewFile(path string) *File {
+	return &File{
+		filepath:    path,
+		permissions: 0644,
+	}
+}
+
+// Observe records the releases of the provided appcast for the provided feed
+// URL. The appcast source checksum is stored as a Record.FeedChecksum.
+func (f *File) Observe(url string, a appcaster.Appcaster) error {
+	if a == nil {
+		return fmt.Errorf("no appcast")
+	}
+
+	return f.ObserveReleases(url, a.Releases(), sourceChecksum(a))
+}
+
+// ObserveReleases records the provided filtered releases for the provided feed
+// URL alongside with the feed content checksum.
+func (f *File) ObserveReleases(url string, releases release.Releaseser, checksum string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	return f.save(merge(records, url, releases, checksum))
+}
+
+// Records returns all the records of the provided feed URL, including all the
+// release revisions, in the order they have been observed for the first time.
+func (f *File) Records(url string) ([]*Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Record
+	for _, rec := range records {
+		if rec.Url == url {
+			result = append(result, rec)
+		}
+	}
+
+	return result, nil
+}
+
+// Releases reconstructs all the releases ever observed for the provided feed
+// URL sorted by versions in the descending order.
+func (f *File) Releases(url string) (release.Releaseser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return reconstruct(records, url), nil
+}
+
+// load loads all the records from the File.filepath. The missing file is
+// considered to be empty.
+func (f *File) load() ([]*Record, error) {
+	content, err := ioutil.ReadFile(f.filepath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), len(content)+1)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		rec := new(Record)
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return nil, fmt.Errorf("line #%d (%s)", line, err.Error())
+		}
+
+		records = append(records, rec)
+	}
+
+	return records, scanner.Err()
+}
+
+// save replaces the File.filepath content with the provided records.
+func (f *File) save(records []*Record) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	for _, rec := range records {
+		if err := encoder.Encode(rec); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.filepath), filepath.Base(f.filepath)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), f.permissions); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.filepath)
+}
+
+// Filepath is a File.filepath getter.
+func (f *File) Filepath() string {
+	return f.filepath
+}
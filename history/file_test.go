@@ -0,0 +1,167 @@
+package history
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// workingDir returns a current working directory path. If it's not available
+// prints an error to os.Stdout and exits with error status 1.
+func workingDir() string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	return pwd
+}
+
+// newTestFile creates a new File instance in the temporary directory for
+// testing purposes and returns its pointer alongside with the cleanup
+// function.
+func newTestFile() (*File, func()) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		panic(err)
+	}
+
+	return NewFile(filepath.Join(dir, "history.jsonl")), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+// newTestAppcast creates a new Sparkle Appcast instance for testing purposes
+// from the provided Sparkle testdata file and returns its pointer.
+func newTestAppcast(name string) *sparkle.Appcast {
+	path := filepath.Join(workingDir(), "../provider/sparkle/testdata/unmarshal/", name)
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+
+	s := new(appcaster.Source)
+	s.SetContent(content)
+
+	a := sparkle.New(s)
+	a.Unmarshal()
+
+	return a
+}
+
+func TestNewFile(t *testing.T) {
+	f := NewFile("history.jsonl")
+	assert.IsType(t, &File{}, f)
+	assert.Equal(t, "history.jsonl", f.Filepath())
+	assert.Equal(t, os.FileMode(0644), f.permissions)
+}
+
+func TestFile_Observe(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	f, cleanup := newTestFile()
+	defer cleanup()
+	defer func() { Now = time.Now }()
+
+	Now = func() time.Time { return time.Date(2016, 5, 13, 0, 0, 0, 0, time.UTC) }
+
+	// test (successful)
+	err := f.Observe(url, newTestAppcast("default.xml"))
+	assert.Nil(t, err)
+
+	Now = func() time.Time { return time.Date(2016, 5, 14, 0, 0, 0, 0, time.UTC) }
+
+	a := newTestAppcast("single.xml")
+	err = f.Observe(url, a)
+	assert.Nil(t, err)
+
+	records, err := f.Records(url)
+	assert.Nil(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, "2.0.0 (200)", records[0].Key)
+	assert.Equal(t, "2016-05-13", records[0].FirstSeen.Format("2006-01-02"))
+	assert.Equal(t, "2016-05-14", records[0].LastSeen.Format("2006-01-02"))
+	assert.Equal(t, a.Source().Checksum().String(), records[0].FeedChecksum)
+	assert.Equal(t, "2016-05-13", records[1].LastSeen.Format("2006-01-02"))
+
+	// test (full history)
+	releases, err := f.Releases(url)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, releases.Len())
+	assert.Equal(t, "2.0.0", releases.First().Version().String())
+	assert.Equal(t, "Release 2.0.0", releases.First().Title())
+
+	// test (another feed)
+	records, err = f.Records("https://example.com/beta.xml")
+	assert.Nil(t, err)
+	assert.Len(t, records, 0)
+
+	// test (error)
+	err = f.Observe(url, nil)
+	assert.EqualError(t, err, "no appcast")
+}
+
+func TestFile_ObserveReleases(t *testing.T) {
+	// preparations
+	f, cleanup := newTestFile()
+	defer cleanup()
+
+	r := newTestRelease("1.0.0", "")
+
+	// test
+	err := f.ObserveReleases("https://example.com/appcast.xml", release.NewReleases([]release.Releaser{r}), "checksum")
+	assert.Nil(t, err)
+
+	content, _ := ioutil.ReadFile(f.Filepath())
+	assert.Contains(t, string(content), `"feed_checksum":"checksum"`)
+	assert.Contains(t, string(content), `"url":"https://example.com/app_1.0.0.dmg"`)
+
+	info, _ := os.Stat(f.Filepath())
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func TestFile_Records(t *testing.T) {
+	// preparations
+	f, cleanup := newTestFile()
+	defer cleanup()
+
+	// test (missing file)
+	records, err := f.Records("https://example.com/appcast.xml")
+	assert.Nil(t, err)
+	assert.Len(t, records, 0)
+
+	// test (error)
+	ioutil.WriteFile(f.Filepath(), []byte("{}\n\ninvalid\n"), 0644)
+
+	records, err = f.Records("https://example.com/appcast.xml")
+	assert.Nil(t, records)
+	assert.EqualError(t, err, "line #3 (invalid character 'i' looking for beginning of value)")
+}
+
+func TestFile_Releases(t *testing.T) {
+	// preparations
+	f, cleanup := newTestFile()
+	defer cleanup()
+
+	// test (missing file)
+	releases, err := f.Releases("https://example.com/appcast.xml")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, releases.Len())
+
+	// test (error)
+	ioutil.WriteFile(f.Filepath(), []byte("invalid"), 0644)
+
+	releases, err = f.Releases("https://example.com/appcast.xml")
+	assert.Nil(t, releases)
+	assert.Error(t, err)
+}
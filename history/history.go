@@ -0,0 +1,283 @@
+// Package history records every release ever observed in the appcasts, so the
+// full release history can be reconstructed even after the old entries have
+// been dropped from the feed.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/diff"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// Now returns the current time. It's used for the first-seen and last-seen
+// timestamps and can be replaced for testing purposes.
+var Now = time.Now
+
+// Storer is the interface that wraps the history storage methods.
+type Storer interface {
+	Observe(url string, a appcaster.Appcaster) error
+	ObserveReleases(url string, releases release.Releaseser, checksum string) error
+	Records(url string) ([]*Record, error)
+	Releases(url string) (release.Releaseser, error)
+}
+
+// Download represents a single recorded release download.
+type Download struct {
+	Url          string `json:"url"`
+	Filetype     string `json:"filetype,omitempty"`
+	Length       int    `json:"length,omitempty"`
+	DsaSignature string `json:"dsa_signature,omitempty"`
+	EdSignature  string `json:"ed_signature,omitempty"`
+	Md5          string `json:"md5,omitempty"`
+	Sha256       string `json:"sha256,omitempty"`
+	ExtraInfo    string `json:"extra_info,omitempty"`
+}
+
+// Record represents a single release observed in the feed.
+type Record struct {
+	// Url specifies the feed URL.
+	Url string `json:"url"`
+
+	// Key specifies the release identity. See diff.Key. The repeated
+	// occurrences of the same release in the feed are suffixed by their
+	// 1-based occurrence number, e.g. "1.0.0 #2".
+	Key string `json:"key"`
+
+	// Revision specifies the 0-based release revision. A new revision is
+	// recorded each time the release has been modified in the feed, so the
+	// previous downloads and checksums are kept.
+	Revision int `json:"revision,omitempty"`
+
+	// Version specifies the original release version.
+	Version string `json:"version,omitempty"`
+
+	// Build specifies the release build.
+	Build string `json:"build,omitempty"`
+
+	// Title specifies the release title.
+	Title string `json:"title,omitempty"`
+
+	// Description specifies the release description.
+	Description string `json:"description,omitempty"`
+
+	// PublishedDateTime specifies the release published datetime. It's nil,
+	// if the datetime has been missing or failed to parse.
+	PublishedDateTime *time.Time `json:"published_datetime,omitempty"`
+
+	// ReleaseNotesLink specifies the release notes link.
+	ReleaseNotesLink string `json:"release_notes_link,omitempty"`
+
+	// MinimumSystemVersion specifies the minimum supported system version.
+	MinimumSystemVersion string `json:"minimum_system_version,omitempty"`
+
+	// MaximumSystemVersion specifies the maximum supported system version.
+	MaximumSystemVersion string `json:"maximum_system_version,omitempty"`
+
+	// IsPreRelease specifies whether the release is not stable.
+	IsPreRelease bool `json:"prerelease,omitempty"`
+
+	// Downloads specify the release downloads.
+	Downloads []Download `json:"downloads,omitempty"`
+
+	// Checksum specifies the SHA256 checksum of the release data above. It
+	// changes when the release has been modified in the feed.
+	Checksum string `json:"checksum"`
+
+	// FeedChecksum specifies the feed content checksum the release has been
+	// last seen in.
+	FeedChecksum string `json:"feed_checksum,omitempty"`
+
+	// FirstSeen specifies when the release revision has been observed for the
+	// first time.
+	FirstSeen time.Time `json:"first_seen"`
+
+	// LastSeen specifies when the release revision has been observed for the
+	// last time.
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// NewRecord returns a new Record instance pointer created from the provided
+// release. The timestamps are left empty.
+func NewRecord(url string, r release.Releaser) *Record {
+	rec := &Record{
+		Url:                  url,
+		Key:                  diff.Key(r),
+		Build:                r.Build(),
+		Title:                r.Title(),
+		Description:          r.Description(),
+		ReleaseNotesLink:     r.ReleaseNotesLink(),
+		MinimumSystemVersion: r.MinimumSystemVersion(),
+		MaximumSystemVersion: r.MaximumSystemVersion(),
+		IsPreRelease:         r.IsPreRelease(),
+	}
+
+	if r.Version() != nil {
+		rec.Version = r.Version().Original()
+	}
+
+	if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+		t := p.Time().UTC()
+		rec.PublishedDateTime = &t
+	}
+
+	for _, d := range r.Downloads() {
+		rec.Downloads = append(rec.Downloads, Download{
+			Url:          d.Url(),
+			Filetype:     d.Filetype(),
+			Length:       d.Length(),
+			DsaSignature: d.DsaSignature(),
+			EdSignature:  d.EdSignature(),
+			Md5:          d.Md5(),
+			Sha256:       d.Sha256(),
+			ExtraInfo:    d.ExtraInfo(),
+		})
+	}
+
+	rec.Checksum = rec.checksum()
+
+	return rec
+}
+
+// checksum returns the SHA256 checksum of the Record release data. The
+// revision, timestamps and checksums are excluded.
+func (r *Record) checksum() string {
+	data := *r
+	data.Revision = 0
+	data.Checksum = ""
+	data.FeedChecksum = ""
+	data.FirstSeen = time.Time{}
+	data.LastSeen = time.Time{}
+
+	content, _ := json.Marshal(data)
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Release returns a new release.Release instance pointer reconstructed from
+// the Record. Releases with malformed versions are kept without a version.
+func (r *Record) Release() *release.Release {
+	rel, _ := release.NewLenient(r.Version, r.Build)
+
+	rel.SetTitle(r.Title)
+	rel.SetDescription(r.Description)
+	rel.SetReleaseNotesLink(r.ReleaseNotesLink)
+	rel.SetMinimumSystemVersion(r.MinimumSystemVersion)
+	rel.SetMaximumSystemVersion(r.MaximumSystemVersion)
+	rel.SetIsPreRelease(r.IsPreRelease)
+
+	if r.PublishedDateTime != nil {
+		t := *r.PublishedDateTime
+		rel.SetPublishedDateTime(release.NewPublishedDateTime(&t))
+	} else {
+		rel.SetPublishedDateTime(release.NewPublishedDateTime())
+	}
+
+	for _, d := range r.Downloads {
+		download := release.NewDownload(d.Url, d.Filetype, d.Length, d.DsaSignature, d.Md5, d.Sha256)
+		download.SetEdSignature(d.EdSignature)
+		download.SetExtraInfo(d.ExtraInfo)
+		rel.AddDownload(*download)
+	}
+
+	return rel
+}
+
+// merge merges the provided observed releases into the provided feed records
+// and returns the result. The unchanged records are updated in place: only
+// their LastSeen and FeedChecksum are replaced. The new records and the new
+// revisions of the modified ones are appended, so the previous data is never
+// lost.
+func merge(records []*Record, url string, releases release.Releaseser, checksum string) []*Record {
+	if releases == nil {
+		return records
+	}
+
+	now := Now().UTC()
+
+	byKey := make(map[string]*Record)
+	for _, rec := range records {
+		if rec.Url == url {
+			byKey[rec.Key] = rec
+		}
+	}
+
+	occurrences := make(map[string]int)
+
+	for _, r := range releases.Filtered() {
+		rec := NewRecord(url, r)
+
+		// the releases listed in the feed more than once are keyed by their
+		// occurrence the same way the diff package does
+		occurrences[rec.Key]++
+		if occurrences[rec.Key] > 1 {
+			rec.Key = fmt.Sprintf("%s #%d", rec.Key, occurrences[rec.Key])
+			rec.Checksum = rec.checksum()
+		}
+
+		rec.FeedChecksum = checksum
+		rec.LastSeen = now
+
+		existing, ok := byKey[rec.Key]
+		if ok && existing.Checksum == rec.Checksum {
+			existing.FeedChecksum = checksum
+			existing.LastSeen = now
+			continue
+		}
+
+		if ok {
+			rec.Revision = existing.Revision + 1
+		}
+
+		rec.FirstSeen = now
+		byKey[rec.Key] = rec
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+// reconstruct returns the releases reconstructed from the latest revisions of
+// the provided feed records sorted by versions in the descending order.
+func reconstruct(records []*Record, url string) release.Releaseser {
+	var items []release.Releaser
+
+	latest := make(map[string]*Record)
+	for _, rec := range records {
+		if rec.Url == url && (latest[rec.Key] == nil || rec.Revision >= latest[rec.Key].Revision) {
+			latest[rec.Key] = rec
+		}
+	}
+
+	for _, rec := range records {
+		if rec.Url == url && latest[rec.Key] == rec {
+			items = append(items, rec.Release())
+		}
+	}
+
+	releases := release.NewReleases(items)
+	releases.SortByVersions(release.DESC)
+
+	return releases
+}
+
+// sourceChecksum returns the SHA256 checksum string of the provided appcast
+// source content. Returns an empty string, if there is no source.
+func sourceChecksum(a appcaster.Appcaster) string {
+	src := a.Source()
+	if src == nil || src.Content() == nil {
+		return ""
+	}
+
+	if src.Checksum() == nil {
+		src.GenerateChecksum(appcaster.SHA256)
+	}
+
+	return src.Checksum().String()
+}
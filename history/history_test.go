@@ -0,0 +1,204 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// newTestRelease creates a new release.Release instance for testing purposes
+// with a single download and returns its pointer.
+func newTestRelease(version string, build string) *release.Release {
+	r, _ := release.NewLenient(version, build)
+
+	t := time.Date(2016, 5, 13, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	r.SetTitle("Release " + version)
+	r.SetDescription("Release " + version + " Description")
+	r.SetPublishedDateTime(release.NewPublishedDateTime(&t))
+	r.SetMinimumSystemVersion("10.9")
+
+	d := release.NewDownload("https://example.com/app_"+version+".dmg", "application/octet-stream", 100000)
+	d.SetSha256("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	r.AddDownload(*d)
+
+	return r
+}
+
+func TestNewRecord(t *testing.T) {
+	// test
+	rec := NewRecord("https://example.com/appcast.xml", newTestRelease("2.0.0", "200"))
+	assert.Equal(t, "https://example.com/appcast.xml", rec.Url)
+	assert.Equal(t, "2.0.0 (200)", rec.Key)
+	assert.Equal(t, "2.0.0", rec.Version)
+	assert.Equal(t, "200", rec.Build)
+	assert.Equal(t, "Release 2.0.0", rec.Title)
+	assert.Equal(t, "2016-05-13 10:00:00 +0000 UTC", rec.PublishedDateTime.String())
+	assert.Len(t, rec.Downloads, 1)
+	assert.Equal(t, "https://example.com/app_2.0.0.dmg", rec.Downloads[0].Url)
+	assert.Len(t, rec.Checksum, 64)
+	assert.True(t, rec.FirstSeen.IsZero())
+
+	// test (checksum)
+	modified := newTestRelease("2.0.0", "200")
+	modified.SetTitle("Release 2.0.0 (Hotfix)")
+	assert.NotEqual(t, rec.Checksum, NewRecord("https://example.com/appcast.xml", modified).Checksum)
+	assert.Equal(t, rec.Checksum, NewRecord("https://example.com/appcast.xml", newTestRelease("2.0.0", "200")).Checksum)
+
+	// test (without published datetime)
+	r := newTestRelease("1.0.0", "")
+	r.SetPublishedDateTime(release.NewPublishedDateTime())
+	assert.Nil(t, NewRecord("https://example.com/appcast.xml", r).PublishedDateTime)
+}
+
+func TestRecord_Release(t *testing.T) {
+	// preparations
+	original := newTestRelease("2.0.0", "200")
+	original.SetIsPreRelease(true)
+
+	// test
+	r := NewRecord("https://example.com/appcast.xml", original).Release()
+	assert.Equal(t, "2.0.0", r.Version().String())
+	assert.Equal(t, "200", r.Build())
+	assert.Equal(t, "Release 2.0.0", r.Title())
+	assert.Equal(t, "Release 2.0.0 Description", r.Description())
+	assert.Equal(t, "10.9", r.MinimumSystemVersion())
+	assert.True(t, r.IsPreRelease())
+	assert.True(t, original.PublishedDateTime().Time().Equal(*r.PublishedDateTime().Time()))
+	assert.Equal(t, original.Downloads(), r.Downloads())
+
+	// test (malformed version)
+	r = NewRecord("https://example.com/appcast.xml", newTestRelease("invalid", "100")).Release()
+	assert.Nil(t, r.Version())
+	assert.Equal(t, "100", r.Build())
+
+	// test (without published datetime)
+	original.SetPublishedDateTime(release.NewPublishedDateTime())
+	r = NewRecord("https://example.com/appcast.xml", original).Release()
+	assert.Nil(t, r.PublishedDateTime().Time())
+}
+
+func TestMerge(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	first := time.Date(2016, 5, 13, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	defer func() { Now = time.Now }()
+
+	// test (new records)
+	Now = func() time.Time { return first }
+
+	records := merge(nil, url, release.NewReleases([]release.Releaser{
+		newTestRelease("1.1.0", ""),
+		newTestRelease("1.0.0", ""),
+	}), "checksum1")
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, first, records[0].FirstSeen)
+	assert.Equal(t, first, records[0].LastSeen)
+	assert.Equal(t, "checksum1", records[0].FeedChecksum)
+
+	// test (updated and dropped records)
+	Now = func() time.Time { return second }
+
+	records = merge(records, url, release.NewReleases([]release.Releaser{
+		newTestRelease("2.0.0", ""),
+		newTestRelease("1.1.0", ""),
+	}), "checksum2")
+
+	assert.Len(t, records, 3)
+	assert.Equal(t, "1.1.0", records[0].Key)
+	assert.Equal(t, first, records[0].FirstSeen)
+	assert.Equal(t, second, records[0].LastSeen)
+	assert.Equal(t, "checksum2", records[0].FeedChecksum)
+	assert.Equal(t, "1.0.0", records[1].Key)
+	assert.Equal(t, first, records[1].LastSeen)
+	assert.Equal(t, "2.0.0", records[2].Key)
+	assert.Equal(t, second, records[2].FirstSeen)
+
+	// test (modified record)
+	third := second.Add(time.Hour)
+	Now = func() time.Time { return third }
+
+	modified := newTestRelease("1.1.0", "")
+	modified.Downloads()[0].SetSha256("modified")
+
+	records = merge(records, url, release.NewReleases([]release.Releaser{
+		newTestRelease("2.0.0", ""),
+		modified,
+	}), "checksum3")
+
+	assert.Len(t, records, 4)
+	assert.Equal(t, "1.1.0", records[0].Key)
+	assert.Equal(t, 0, records[0].Revision)
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", records[0].Downloads[0].Sha256)
+	assert.Equal(t, second, records[0].LastSeen)
+	assert.Equal(t, "checksum2", records[0].FeedChecksum)
+	assert.Equal(t, "1.1.0", records[3].Key)
+	assert.Equal(t, 1, records[3].Revision)
+	assert.Equal(t, "modified", records[3].Downloads[0].Sha256)
+	assert.NotEqual(t, records[0].Checksum, records[3].Checksum)
+	assert.Equal(t, third, records[3].FirstSeen)
+	assert.Equal(t, "checksum3", records[3].FeedChecksum)
+
+	records = merge(records, url, release.NewReleases([]release.Releaser{modified}), "checksum4")
+	assert.Len(t, records, 4)
+	assert.Equal(t, "checksum2", records[0].FeedChecksum)
+	assert.Equal(t, "checksum4", records[3].FeedChecksum)
+
+	// test (duplicate releases)
+	duplicates := release.NewReleases([]release.Releaser{
+		newTestRelease("3.0.0", ""),
+		newTestRelease("3.0.0", ""),
+	})
+
+	records = merge(records, url, duplicates, "checksum5")
+	assert.Len(t, records, 6)
+	assert.Equal(t, "3.0.0", records[4].Key)
+	assert.Equal(t, "3.0.0 #2", records[5].Key)
+
+	records = merge(records, url, duplicates, "checksum6")
+	assert.Len(t, records, 6)
+	assert.Equal(t, 0, records[4].Revision)
+	assert.Equal(t, 0, records[5].Revision)
+	assert.Equal(t, "checksum6", records[5].FeedChecksum)
+	assert.Equal(t, 5, reconstruct(records, url).Len())
+
+	// test (another feed)
+	records = merge(records, "https://example.com/beta.xml", release.NewReleases([]release.Releaser{
+		newTestRelease("1.1.0", ""),
+	}), "")
+	assert.Len(t, records, 7)
+
+	// test (nil releases)
+	assert.Len(t, merge(records, url, nil, ""), 7)
+}
+
+func TestReconstruct(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	records := []*Record{
+		NewRecord(url, newTestRelease("1.0.0", "")),
+		NewRecord("https://example.com/beta.xml", newTestRelease("3.0.0", "")),
+		NewRecord(url, newTestRelease("2.0.0", "")),
+	}
+
+	// test
+	releases := reconstruct(records, url)
+	assert.Equal(t, 2, releases.Len())
+	assert.Equal(t, "2.0.0", releases.First().Version().String())
+
+	// test (revisions)
+	modified := newTestRelease("2.0.0", "")
+	modified.SetTitle("Release 2.0.0 (Hotfix)")
+
+	revision := NewRecord(url, modified)
+	revision.Revision = 1
+	records = append(records, revision)
+
+	releases = reconstruct(records, url)
+	assert.Equal(t, 2, releases.Len())
+	assert.Equal(t, "Release 2.0.0 (Hotfix)", releases.First().Title())
+}
@@ -0,0 +1,466 @@
+// Package lint checks the appcasts against the named rules and reports the
+// found issues alongside with their severities, positions and suggested fixes.
+//
+// Unlike the flat unmarshalling errors, each Issue points to the feed item
+// (and its XML line, when available) it has been found in. Rules can be
+// limited to the specific providers, so the same Linter can be run against any
+// supported appcast.
+package lint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/client"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// Now returns the current time. It's used by the rules comparing the dates and
+// can be replaced for testing purposes.
+var Now = time.Now
+
+// Severity holds different supported issue severities.
+type Severity int
+
+const (
+	// Info represents an issue that doesn't affect the updates.
+	Info Severity = iota
+
+	// Warning represents an issue that may affect the updates.
+	Warning
+
+	// Error represents an issue that breaks the updates.
+	Error
+)
+
+var severityNames = [...]string{
+	"info",
+	"warning",
+	"error",
+}
+
+// String returns the string representation of the Severity.
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity returns the Severity from the provided string representation.
+// Returns an error, if the severity is unknown.
+func ParseSeverity(value string) (Severity, error) {
+	for i, name := range severityNames {
+		if name == value {
+			return Severity(i), nil
+		}
+	}
+
+	return Info, fmt.Errorf("unknown severity: %s", value)
+}
+
+// Position represents an issue position in the feed.
+type Position struct {
+	// Item specifies the 1-based feed item index. It's 0, if the issue isn't
+	// related to a single item.
+	Item int
+
+	// Line specifies the 1-based XML line. It's 0, if unknown.
+	Line int
+}
+
+// String returns the string representation of the Position.
+func (p Position) String() string {
+	var parts []string
+
+	if p.Line > 0 {
+		parts = append(parts, fmt.Sprintf("line %d", p.Line))
+	}
+
+	if p.Item > 0 {
+		parts = append(parts, fmt.Sprintf("item #%d", p.Item))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Issue represents a single found issue.
+type Issue struct {
+	// Rule specifies the name of the rule that has found the issue.
+	Rule string
+
+	// Severity specifies the issue severity.
+	Severity Severity
+
+	// Position specifies the issue position in the feed.
+	Position Position
+
+	// Message specifies the issue description.
+	Message string
+
+	// Fix specifies the suggested fix.
+	Fix string
+}
+
+// String returns the string representation of the Issue.
+func (i *Issue) String() string {
+	prefix := ""
+	if p := i.Position.String(); p != "" {
+		prefix = p + ": "
+	}
+
+	return fmt.Sprintf("%s%s [%s]: %s", prefix, i.Severity, i.Rule, i.Message)
+}
+
+// Rule represents a single named lint rule.
+type Rule struct {
+	// Name specifies the unique rule name, for example: "missing-length".
+	Name string
+
+	// Severity specifies the default severity of the found issues.
+	Severity Severity
+
+	// Providers specify the providers the rule supports. The rule supports
+	// all providers, if empty.
+	Providers []provider.Provider
+
+	// Remote specifies whether the rule makes the remote requests. Such rules
+	// are run only when the Linter client is set.
+	Remote bool
+
+	// Check checks the appcast and returns the found issues. The Issue.Rule
+	// and Issue.Severity are set by the Linter.
+	Check func(c *Context) []*Issue
+}
+
+// Supports checks whether the rule supports the provided provider.
+func (r *Rule) Supports(p provider.Provider) bool {
+	if len(r.Providers) == 0 {
+		return true
+	}
+
+	for _, supported := range r.Providers {
+		if supported == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Linter represents the appcast linter.
+type Linter struct {
+	rules      []*Rule
+	severities map[string]Severity
+	disabled   map[string]bool
+	client     *client.Client
+}
+
+// New returns a new Linter instance pointer with the provided rules. The
+// DefaultRules are used, if none are provided.
+func New(rules ...*Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+
+	return &Linter{
+		rules:      rules,
+		severities: make(map[string]Severity),
+		disabled:   make(map[string]bool),
+	}
+}
+
+// Rules is a Linter.rules getter.
+func (l *Linter) Rules() []*Rule {
+	return l.rules
+}
+
+// rule returns the rule with the provided name. Returns nil, if there is no
+// such rule.
+func (l *Linter) rule(name string) *Rule {
+	for _, r := range l.rules {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// SetSeverity overrides the severity of the provided rule. Returns an error, if
+// there is no such rule.
+func (l *Linter) SetSeverity(name string, s Severity) error {
+	if l.rule(name) == nil {
+		return fmt.Errorf("unknown rule: %s", name)
+	}
+
+	l.severities[name] = s
+
+	return nil
+}
+
+// Disable disables the provided rule. Returns an error, if there is no such
+// rule.
+func (l *Linter) Disable(name string) error {
+	if l.rule(name) == nil {
+		return fmt.Errorf("unknown rule: %s", name)
+	}
+
+	l.disabled[name] = true
+
+	return nil
+}
+
+// SetClient sets the client used by the remote rules. The remote rules are
+// skipped, if the client is nil.
+func (l *Linter) SetClient(c *client.Client) {
+	l.client = c
+}
+
+// Lint checks the provided unmarshalled appcast using the rules supporting its
+// source provider and returns the found issues ordered by their positions.
+// Optionally, the unmarshalling errors can be passed to be reported as issues
+// as well.
+func (l *Linter) Lint(a appcaster.Appcaster, errs ...error) []*Issue {
+	c := newContext(a, errs, l.client)
+
+	var issues []*Issue
+
+	for _, r := range l.rules {
+		if l.disabled[r.Name] || !r.Supports(c.Provider) || (r.Remote && c.Client == nil) {
+			continue
+		}
+
+		severity := r.Severity
+		if s, ok := l.severities[r.Name]; ok {
+			severity = s
+		}
+
+		for _, i := range r.Check(c) {
+			i.Rule = r.Name
+			i.Severity = severity
+			issues = append(issues, i)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Position.Item < issues[j].Position.Item
+	})
+
+	return issues
+}
+
+// item represents a single feed item location in the source content.
+type item struct {
+	start int
+	end   int
+	line  int
+}
+
+// namespace represents a single XML namespace declaration.
+type namespace struct {
+	prefix string
+	url    string
+	line   int
+}
+
+// Context represents the appcast being checked by the rules.
+type Context struct {
+	// Appcast specifies the checked appcast.
+	Appcast appcaster.Appcaster
+
+	// Provider specifies the appcast source provider.
+	Provider provider.Provider
+
+	// Errors specify the unmarshalling errors.
+	Errors []error
+
+	// Client specifies the client for the remote rules. It's nil, if the
+	// remote rules are disabled.
+	Client *client.Client
+
+	content    []byte
+	items      []item
+	namespaces []namespace
+	positions  map[release.Releaser]Position
+}
+
+// newContext returns a new Context instance pointer for the provided appcast.
+func newContext(a appcaster.Appcaster, errs []error, c *client.Client) *Context {
+	ctx := &Context{
+		Appcast:   a,
+		Provider:  provider.Unknown,
+		Errors:    errs,
+		Client:    c,
+		positions: make(map[release.Releaser]Position),
+	}
+
+	if src := a.Source(); src != nil {
+		ctx.content = src.Content()
+		if p, ok := src.Provider().(provider.Provider); ok {
+			ctx.Provider = p
+		}
+	}
+
+	ctx.scan()
+	ctx.locate()
+
+	return ctx
+}
+
+// Releases returns all the appcast releases. The filters are ignored.
+func (c *Context) Releases() []release.Releaser {
+	if c.Appcast.Releases() == nil {
+		return nil
+	}
+
+	return c.Appcast.Releases().Original()
+}
+
+// Position returns the position of the item the provided release has been
+// unmarshalled from. Returns an empty Position, if unknown.
+func (c *Context) Position(r release.Releaser) Position {
+	return c.positions[r]
+}
+
+// ItemPosition returns the position of the provided 1-based feed item index.
+func (c *Context) ItemPosition(i int) Position {
+	p := Position{Item: i}
+	if i > 0 && i <= len(c.items) {
+		p.Line = c.items[i-1].line
+	}
+
+	return p
+}
+
+// itemNames holds the XML element names representing the feed items.
+var itemNames = map[string]bool{
+	"item":    true,
+	"entry":   true,
+	"release": true,
+}
+
+// scan scans the XML source content for the items and namespace declarations.
+// Non-XML content is ignored.
+func (c *Context) scan() {
+	trimmed := bytes.TrimLeft(c.content, "\xef\xbb\xbf \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '<' {
+		return
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(c.content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// only the offsets matter, so the content is kept as is
+		return input, nil
+	}
+
+	var stack []int
+
+	for {
+		offset := int(decoder.InputOffset())
+
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			line := c.line(offset)
+
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					c.namespaces = append(c.namespaces, namespace{attr.Name.Local, attr.Value, line})
+				}
+			}
+
+			if itemNames[t.Name.Local] {
+				c.items = append(c.items, item{start: offset, line: line})
+				stack = append(stack, len(c.items)-1)
+			} else {
+				stack = append(stack, -1)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				continue
+			}
+
+			if i := stack[len(stack)-1]; i >= 0 {
+				c.items[i].end = int(decoder.InputOffset())
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// line returns the 1-based line of the provided content offset.
+func (c *Context) line(offset int) int {
+	return bytes.Count(c.content[:offset], []byte("\n")) + 1
+}
+
+// locate maps the releases to their feed items. Releases are created in the
+// items order, but the failed items are skipped, so each release is matched
+// with the next item containing its download URL or version.
+func (c *Context) locate() {
+	next := 0
+
+	for _, r := range c.Releases() {
+		for i := next; i < len(c.items); i++ {
+			if c.matches(c.items[i], r) {
+				c.positions[r] = c.ItemPosition(i + 1)
+				next = i + 1
+				break
+			}
+		}
+	}
+}
+
+// matches checks whether the provided item content contains the provided
+// release download URL or version.
+func (c *Context) matches(it item, r release.Releaser) bool {
+	if it.end <= it.start {
+		return false
+	}
+
+	raw := c.content[it.start:it.end]
+
+	var needles []string
+
+	for _, d := range r.Downloads() {
+		if d.Url() != "" {
+			needles = append(needles, d.Url(), escape(d.Url()))
+		}
+	}
+
+	if len(needles) == 0 {
+		if r.Version() != nil {
+			needles = append(needles, r.Version().Original())
+		}
+
+		if r.Build() != "" {
+			needles = append(needles, r.Build())
+		}
+	}
+
+	for _, needle := range needles {
+		if bytes.Contains(raw, []byte(needle)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// escape returns the XML-escaped string.
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+
+	return buf.String()
+}
@@ -0,0 +1,195 @@
+package lint
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast"
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider"
+)
+
+// testdataPath returns a full path for the provided testdata paths.
+func testdataPath(paths ...string) string {
+	_, b, _, _ := runtime.Caller(0)
+	dir := filepath.Dir(b)
+
+	return filepath.Join(append([]string{dir, "testdata"}, paths...)...)
+}
+
+// newTestAppcast loads the provided testdata file and returns the unmarshalled
+// provider-specific appcast alongside with the unmarshalling errors.
+func newTestAppcast(paths ...string) (appcaster.Appcaster, []error) {
+	a := appcast.New()
+	p, errs := a.LoadFromLocalSource(testdataPath(paths...))
+	if p == nil {
+		panic(errs)
+	}
+
+	return p, errs
+}
+
+// setTestNow replaces the Now function with the one returning the fixed time
+// between the testdata releases. The Now should be restored afterwards.
+func setTestNow() {
+	Now = func() time.Time { return time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC) }
+}
+
+func TestSeverity_String(t *testing.T) {
+	assert.Equal(t, "info", Info.String())
+	assert.Equal(t, "warning", Warning.String())
+	assert.Equal(t, "error", Error.String())
+}
+
+func TestParseSeverity(t *testing.T) {
+	testCases := map[string]Severity{
+		"info":    Info,
+		"warning": Warning,
+		"error":   Error,
+	}
+
+	for value, severity := range testCases {
+		s, err := ParseSeverity(value)
+		assert.Nil(t, err)
+		assert.Equal(t, severity, s)
+	}
+
+	_, err := ParseSeverity("fatal")
+	assert.EqualError(t, err, "unknown severity: fatal")
+}
+
+func TestPosition_String(t *testing.T) {
+	assert.Equal(t, "", Position{}.String())
+	assert.Equal(t, "line 12", Position{Line: 12}.String())
+	assert.Equal(t, "item #3", Position{Item: 3}.String())
+	assert.Equal(t, "line 12, item #3", Position{Item: 3, Line: 12}.String())
+}
+
+func TestIssue_String(t *testing.T) {
+	i := &Issue{
+		Rule:     "missing-length",
+		Severity: Warning,
+		Position: Position{Item: 3, Line: 12},
+		Message:  "download has no length",
+	}
+	assert.Equal(t, "line 12, item #3: warning [missing-length]: download has no length", i.String())
+
+	i.Position = Position{}
+	assert.Equal(t, "warning [missing-length]: download has no length", i.String())
+}
+
+func TestRule_Supports(t *testing.T) {
+	r := &Rule{Name: "test"}
+	assert.True(t, r.Supports(provider.Sparkle))
+	assert.True(t, r.Supports(provider.Unknown))
+
+	r.Providers = []provider.Provider{provider.Sparkle}
+	assert.True(t, r.Supports(provider.Sparkle))
+	assert.False(t, r.Supports(provider.GitHub))
+}
+
+func TestNew(t *testing.T) {
+	l := New()
+	assert.IsType(t, Linter{}, *l)
+	assert.Len(t, l.Rules(), len(DefaultRules()))
+	assert.Nil(t, l.client)
+
+	r := &Rule{Name: "test"}
+	l = New(r)
+	assert.Equal(t, []*Rule{r}, l.Rules())
+}
+
+func TestLinter_SetSeverity(t *testing.T) {
+	// preparations
+	l := New()
+	p, errs := newTestAppcast("issues.xml")
+
+	// test
+	assert.Nil(t, l.SetSeverity("unknown-namespace", Error))
+	assert.EqualError(t, l.SetSeverity("unknown", Error), "unknown rule: unknown")
+
+	for _, i := range l.Lint(p, errs...) {
+		if i.Rule == "unknown-namespace" {
+			assert.Equal(t, Error, i.Severity)
+		}
+	}
+}
+
+func TestLinter_Disable(t *testing.T) {
+	// preparations
+	l := New()
+	p, errs := newTestAppcast("issues.xml")
+
+	// test
+	assert.Nil(t, l.Disable("missing-signature"))
+	assert.EqualError(t, l.Disable("unknown"), "unknown rule: unknown")
+
+	for _, i := range l.Lint(p, errs...) {
+		assert.NotEqual(t, "missing-signature", i.Rule)
+	}
+}
+
+func TestLinter_Lint(t *testing.T) {
+	// preparations
+	defer func() { Now = time.Now }()
+	setTestNow()
+
+	// valid
+	p, errs := newTestAppcast("valid.xml")
+	assert.Len(t, errs, 0)
+	assert.Len(t, New().Lint(p, errs...), 0)
+
+	// issues
+	p, errs = newTestAppcast("issues.xml")
+	assert.Len(t, errs, 1)
+
+	var actual []string
+	for _, i := range New().Lint(p, errs...) {
+		actual = append(actual, i.String())
+	}
+
+	assert.Equal(t, []string{
+		"line 2: info [unknown-namespace]: unknown namespace custom: https://example.com/xml-namespaces/custom",
+		"line 8, item #1: warning [future-pubdate]: release 2.0.0 is published in the future: Sat, 13 May 2017 12:00:00 +0200",
+		"line 13, item #2: warning [missing-length]: download http://example.com/app_1.1.0.dmg has no length",
+		"line 13, item #2: warning [insecure-url]: download http://example.com/app_1.1.0.dmg is served over HTTP",
+		"line 13, item #2: warning [non-monotonic-dates]: release 1.1.0 is published after the newer release 1.1.1",
+		"line 18, item #3: error [duplicate-version]: release 1.1.0 (110) is duplicated",
+		"line 18, item #3: warning [non-monotonic-dates]: release 1.1.0 is published after the newer release 1.1.1",
+		"line 23, item #4: error [invalid-release]: malformed version: invalid",
+		"line 23, item #4: warning [missing-signature]: download https://example.com/app_1.0.1.dmg has no signature",
+		"line 28, item #5: warning [missing-signature]: download https://example.com/app_1.1.1.dmg has no signature",
+	}, actual)
+
+	// unsupported provider rules
+	p, errs = newTestAppcast("..", "..", "testdata", "github.xml")
+	for _, i := range New().Lint(p, errs...) {
+		assert.NotEqual(t, "missing-signature", i.Rule)
+	}
+}
+
+func TestContext_Position(t *testing.T) {
+	// preparations
+	p, errs := newTestAppcast("issues.xml")
+	c := newContext(p, errs, nil)
+
+	// test
+	assert.Equal(t, provider.Sparkle, c.Provider)
+	assert.Len(t, c.Releases(), 5)
+	assert.Equal(t, Position{Item: 1, Line: 8}, c.Position(c.Releases()[0]))
+	assert.Equal(t, Position{Item: 5, Line: 28}, c.Position(c.Releases()[4]))
+}
+
+func TestContext_ItemPosition(t *testing.T) {
+	// preparations
+	p, errs := newTestAppcast("issues.xml")
+	c := newContext(p, errs, nil)
+
+	// test
+	assert.Equal(t, Position{Item: 2, Line: 13}, c.ItemPosition(2))
+	assert.Equal(t, Position{Item: 6}, c.ItemPosition(6))
+}
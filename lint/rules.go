@@ -0,0 +1,342 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/client"
+	"github.com/victorpopkov/go-appcast/diff"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// knownNamespaces holds the XML namespaces used by the supported providers.
+var knownNamespaces = map[string]bool{
+	"http://www.andymatuschak.org/xml-namespaces/sparkle":            true,
+	"http://purl.org/dc/elements/1.1/":                               true,
+	"http://purl.org/rss/1.0/modules/content/":                       true,
+	"http://www.w3.org/2005/Atom":                                    true,
+	"http://search.yahoo.com/mrss/":                                  true,
+	"http://video.search.yahoo.com/mrss/":                            true,
+	"https://sourceforge.net/api/files.rdf#":                         true,
+	"https://sourceforge.net/api/sfelements.rdf#":                    true,
+	"http://usefulinc.com/ns/doap#":                                  true,
+	"http://schemas.microsoft.com/ado/2007/08/dataservices":          true,
+	"http://schemas.microsoft.com/ado/2007/08/dataservices/metadata": true,
+	"http://www.itunes.com/dtds/podcast-1.0.dtd":                     true,
+}
+
+// DefaultRules returns new instances of all the built-in rules.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			Name:     "invalid-release",
+			Severity: Error,
+			Check:    checkInvalidRelease,
+		},
+		{
+			Name:     "malformed-xml",
+			Severity: Warning,
+			Check:    checkMalformedXML,
+		},
+		{
+			Name:      "missing-length",
+			Severity:  Warning,
+			Providers: []provider.Provider{provider.Sparkle, provider.SourceForge, provider.Generic},
+			Check:     checkMissingLength,
+		},
+		{
+			Name:     "length-mismatch",
+			Severity: Error,
+			Remote:   true,
+			Check:    checkLengthMismatch,
+		},
+		{
+			Name:     "insecure-url",
+			Severity: Warning,
+			Check:    checkInsecureUrl,
+		},
+		{
+			Name:     "duplicate-version",
+			Severity: Error,
+			Check:    checkDuplicateVersion,
+		},
+		{
+			Name:     "non-monotonic-dates",
+			Severity: Warning,
+			Check:    checkNonMonotonicDates,
+		},
+		{
+			Name:      "missing-signature",
+			Severity:  Warning,
+			Providers: []provider.Provider{provider.Sparkle},
+			Check:     checkMissingSignature,
+		},
+		{
+			Name:     "future-pubdate",
+			Severity: Warning,
+			Check:    checkFuturePubDate,
+		},
+		{
+			Name:     "unknown-namespace",
+			Severity: Info,
+			Check:    checkUnknownNamespace,
+		},
+	}
+}
+
+// checkInvalidRelease reports the unmarshalling errors.
+func checkInvalidRelease(c *Context) (issues []*Issue) {
+	for _, err := range c.Errors {
+		var r *appcaster.Repair
+		if errors.As(err, &r) {
+			continue
+		}
+
+		var re *appcaster.ReleaseError
+		if !errors.As(err, &re) {
+			issues = append(issues, &Issue{Message: err.Error()})
+			continue
+		}
+
+		issue := &Issue{Position: c.ItemPosition(re.Index), Message: re.Err.Error()}
+		if re.Line > 0 {
+			issue.Position.Line = re.Line
+		}
+
+		switch {
+		case errors.Is(re.Err, appcaster.ErrNoVersion):
+			issue.Fix = "Add the release version"
+		case re.Field == "version":
+			issue.Fix = "Use the semantic version, for example: 1.2.3"
+		case re.Field == "publishedDateTime":
+			issue.Fix = "Use the RFC 1123 date, for example: Mon, 02 Jan 2006 15:04:05 -0700"
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// checkMalformedXML reports the repairs of the malformed XML made in the
+// lenient mode.
+func checkMalformedXML(c *Context) (issues []*Issue) {
+	for _, err := range c.Errors {
+		var r *appcaster.Repair
+		if errors.As(err, &r) {
+			issues = append(issues, &Issue{
+				Position: Position{Line: r.Line},
+				Message:  r.Message,
+				Fix:      "Escape the special characters and wrap the HTML markup in CDATA",
+			})
+		}
+	}
+
+	return issues
+}
+
+// checkMissingLength reports the downloads without the length.
+func checkMissingLength(c *Context) (issues []*Issue) {
+	for _, r := range c.Releases() {
+		for _, d := range r.Downloads() {
+			if d.Url() != "" && d.Length() <= 0 {
+				issues = append(issues, &Issue{
+					Position: c.Position(r),
+					Message:  fmt.Sprintf("download %s has no length", d.Url()),
+					Fix:      "Add the download length in bytes",
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkLengthMismatch reports the downloads which length differs from the
+// Content-Length returned by the HEAD request. The downloads without the
+// length, the failed requests and the unknown Content-Length are skipped.
+func checkLengthMismatch(c *Context) (issues []*Issue) {
+	for _, r := range c.Releases() {
+		for _, d := range r.Downloads() {
+			if d.Url() == "" || d.Length() <= 0 {
+				continue
+			}
+
+			length, err := contentLength(c.Client, d.Url())
+			if err != nil || length <= 0 || length == int64(d.Length()) {
+				continue
+			}
+
+			issues = append(issues, &Issue{
+				Position: c.Position(r),
+				Message:  fmt.Sprintf("download %s length %d doesn't match the Content-Length %d", d.Url(), d.Length(), length),
+				Fix:      fmt.Sprintf("Set the download length to %d", length),
+			})
+		}
+	}
+
+	return issues
+}
+
+// contentLength returns the Content-Length of the provided URL using the HEAD
+// request. Returns -1, if the length is unknown or the request fails.
+func contentLength(c *client.Client, url string) (int64, error) {
+	req, err := client.NewRequest(url)
+	if err != nil {
+		return -1, err
+	}
+
+	req.HTTPRequest.Method = "HEAD"
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return -1, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return resp.ContentLength, nil
+}
+
+// checkInsecureUrl reports the downloads served over the plain HTTP.
+func checkInsecureUrl(c *Context) (issues []*Issue) {
+	for _, r := range c.Releases() {
+		for _, d := range r.Downloads() {
+			if strings.HasPrefix(strings.ToLower(d.Url()), "http://") {
+				issues = append(issues, &Issue{
+					Position: c.Position(r),
+					Message:  fmt.Sprintf("download %s is served over HTTP", d.Url()),
+					Fix:      fmt.Sprintf("Use the HTTPS URL: https://%s", d.Url()[len("http://"):]),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkDuplicateVersion reports the releases with the same version and build
+// as one of the previous releases.
+func checkDuplicateVersion(c *Context) (issues []*Issue) {
+	seen := make(map[string]bool)
+
+	for _, r := range c.Releases() {
+		key := diff.Key(r)
+		if key == "" {
+			continue
+		}
+
+		if seen[key] {
+			issues = append(issues, &Issue{
+				Position: c.Position(r),
+				Message:  fmt.Sprintf("release %s is duplicated", key),
+				Fix:      "Remove the duplicate release or bump its version",
+			})
+		}
+
+		seen[key] = true
+	}
+
+	return issues
+}
+
+// checkNonMonotonicDates reports the releases published after a newer release
+// of the same minor line, for example, 1.2.1 published after 1.2.2. Releases
+// of the different lines are not compared, so the backports are allowed.
+func checkNonMonotonicDates(c *Context) (issues []*Issue) {
+	lines := make(map[string][]release.Releaser)
+
+	for _, r := range c.Releases() {
+		if r.Version() == nil || r.PublishedDateTime() == nil || r.PublishedDateTime().Time() == nil {
+			continue
+		}
+
+		s := r.Version().Segments()
+		key := fmt.Sprintf("%d.%d", s[0], s[1])
+		lines[key] = append(lines[key], r)
+	}
+
+	for _, r := range c.Releases() {
+		if r.Version() == nil || r.PublishedDateTime() == nil || r.PublishedDateTime().Time() == nil {
+			continue
+		}
+
+		s := r.Version().Segments()
+		for _, newer := range lines[fmt.Sprintf("%d.%d", s[0], s[1])] {
+			if newer.Version().GreaterThan(r.Version()) && r.PublishedDateTime().Time().After(*newer.PublishedDateTime().Time()) {
+				issues = append(issues, &Issue{
+					Position: c.Position(r),
+					Message:  fmt.Sprintf("release %s is published after the newer release %s", r.Version().Original(), newer.Version().Original()),
+					Fix:      "Check the published date and the version of both releases",
+				})
+				break
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkMissingSignature reports the downloads without either the EdDSA or the
+// DSA signature.
+func checkMissingSignature(c *Context) (issues []*Issue) {
+	for _, r := range c.Releases() {
+		for _, d := range r.Downloads() {
+			if d.Url() != "" && !d.HasSignature() {
+				issues = append(issues, &Issue{
+					Position: c.Position(r),
+					Message:  fmt.Sprintf("download %s has no signature", d.Url()),
+					Fix:      "Sign the update using the Sparkle \"sign_update\" tool and add the sparkle:edSignature attribute",
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkFuturePubDate reports the releases published in the future.
+func checkFuturePubDate(c *Context) (issues []*Issue) {
+	now := Now()
+
+	for _, r := range c.Releases() {
+		p := r.PublishedDateTime()
+		if p == nil || p.Time() == nil || !p.Time().After(now) {
+			continue
+		}
+
+		issues = append(issues, &Issue{
+			Position: c.Position(r),
+			Message:  fmt.Sprintf("release %s is published in the future: %s", r.VersionOrBuildString(), p.Time().Format(time.RFC1123Z)),
+			Fix:      "Set the actual published date",
+		})
+	}
+
+	return issues
+}
+
+// checkUnknownNamespace reports the XML namespaces not used by any supported
+// provider.
+func checkUnknownNamespace(c *Context) (issues []*Issue) {
+	for _, ns := range c.namespaces {
+		if knownNamespaces[ns.url] {
+			continue
+		}
+
+		issues = append(issues, &Issue{
+			Position: Position{Line: ns.line},
+			Message:  fmt.Sprintf("unknown namespace %s: %s", ns.prefix, ns.url),
+			Fix:      "Remove the namespace, if it's unused",
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,264 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/client"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// newTestRelease creates a new release.Release instance for testing purposes
+// with a single download published at the provided day of May 2016 and returns
+// its pointer.
+func newTestRelease(version string, day int) *release.Release {
+	r, err := release.New(version, "")
+	if r == nil {
+		panic(err)
+	}
+
+	t := time.Date(2016, 5, day, 12, 0, 0, 0, time.UTC)
+	r.SetPublishedDateTime(release.NewPublishedDateTime(&t))
+	r.AddDownload(*release.NewDownload(
+		"https://example.com/app_"+version+".dmg",
+		"application/octet-stream",
+		100000,
+		"MC0CFBfeCa1JyW30nbkBwainOzrN6EQuAh=",
+	))
+
+	return r
+}
+
+// newTestContext creates a new Context instance for testing purposes holding
+// the provided releases without any source and returns its pointer.
+func newTestContext(releases ...release.Releaser) *Context {
+	a := new(appcaster.Appcast)
+	a.SetReleases(release.NewReleases(releases))
+
+	return newContext(a, nil, nil)
+}
+
+// getRule returns the rule from the DefaultRules by its name.
+func getRule(name string) *Rule {
+	for _, r := range DefaultRules() {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	panic("unknown rule: " + name)
+}
+
+func TestDefaultRules(t *testing.T) {
+	var names []string
+	for _, r := range DefaultRules() {
+		assert.NotNil(t, r.Check)
+		names = append(names, r.Name)
+	}
+
+	assert.Equal(t, []string{
+		"invalid-release",
+		"malformed-xml",
+		"missing-length",
+		"length-mismatch",
+		"insecure-url",
+		"duplicate-version",
+		"non-monotonic-dates",
+		"missing-signature",
+		"future-pubdate",
+		"unknown-namespace",
+	}, names)
+
+	// each call returns new instances
+	assert.False(t, DefaultRules()[0] == DefaultRules()[0])
+}
+
+func TestCheckInvalidRelease(t *testing.T) {
+	// preparations
+	c := newTestContext()
+	re := appcaster.NewReleaseError(2, "publishedDateTime", "invalid", errors.New("parsing of the published datetime failed"))
+	re.Line = 12
+
+	c.Errors = []error{
+		appcaster.NewReleaseError(0, "version", "", appcaster.ErrNoVersion),
+		appcaster.NewReleaseError(1, "version", "invalid", errors.New("malformed version: invalid")),
+		re,
+		appcaster.NewReleaseError(3, "title", "", errors.New("unexpected")),
+		errors.New("unexpected"),
+		&appcaster.Repair{Line: 5, Message: "unescaped \"&\" escaped"},
+	}
+
+	// test
+	issues := checkInvalidRelease(c)
+	assert.Len(t, issues, 5)
+
+	assert.Equal(t, Position{Item: 1}, issues[0].Position)
+	assert.Equal(t, "no version", issues[0].Message)
+	assert.Equal(t, "Add the release version", issues[0].Fix)
+
+	assert.Equal(t, Position{Item: 2}, issues[1].Position)
+	assert.Equal(t, "Use the semantic version, for example: 1.2.3", issues[1].Fix)
+
+	assert.Equal(t, Position{Item: 3, Line: 12}, issues[2].Position)
+	assert.Contains(t, issues[2].Fix, "RFC 1123")
+
+	assert.Equal(t, Position{Item: 4}, issues[3].Position)
+	assert.Equal(t, "unexpected", issues[3].Message)
+	assert.Empty(t, issues[3].Fix)
+
+	assert.Equal(t, Position{}, issues[4].Position)
+	assert.Equal(t, "unexpected", issues[4].Message)
+
+	// test (wrapped)
+	c.Errors = []error{
+		fmt.Errorf("feed: %w", appcaster.NewReleaseError(0, "version", "", appcaster.ErrNoVersion)),
+		fmt.Errorf("feed: %w", &appcaster.Repair{Line: 5, Message: "unescaped \"&\" escaped"}),
+	}
+
+	issues = checkInvalidRelease(c)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, Position{Item: 1}, issues[0].Position)
+	assert.Equal(t, "Add the release version", issues[0].Fix)
+	assert.Len(t, checkMalformedXML(c), 1)
+}
+
+func TestCheckMalformedXML(t *testing.T) {
+	// preparations
+	c := newTestContext()
+	c.Errors = []error{
+		appcaster.NewReleaseError(0, "version", "", appcaster.ErrNoVersion),
+		&appcaster.Repair{Line: 5, Column: 10, Message: "unescaped \"&\" escaped"},
+	}
+
+	// test
+	issues := checkMalformedXML(c)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, Position{Line: 5}, issues[0].Position)
+	assert.Equal(t, "unescaped \"&\" escaped", issues[0].Message)
+	assert.Contains(t, issues[0].Fix, "CDATA")
+}
+
+func TestCheckLengthMismatch(t *testing.T) {
+	// preparations
+	c := client.New()
+	c.HTTPClient = new(http.Client)
+
+	httpmock.ActivateNonDefault(c.HTTPClient)
+	defer httpmock.DeactivateAndReset()
+
+	responder := func(length int64) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, "")
+			resp.ContentLength = length
+			return resp, nil
+		}
+	}
+
+	httpmock.RegisterResponder("HEAD", "https://example.com/app_2.0.0.dmg", responder(200000))
+	httpmock.RegisterResponder("HEAD", "https://example.com/app_1.1.0.dmg", responder(100000))
+	httpmock.RegisterResponder("HEAD", "https://example.com/app_1.0.0.dmg", httpmock.NewStringResponder(404, ""))
+
+	l := New(getRule("length-mismatch"))
+	p, errs := newTestAppcast("valid.xml")
+	p.SetReleases(release.NewReleases(append(p.Releases().Original(), newTestRelease("1.0.0", 10))))
+
+	// test (without client)
+	assert.Len(t, l.Lint(p, errs...), 0)
+
+	// test (with client)
+	l.SetClient(c)
+
+	issues := l.Lint(p, errs...)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "length-mismatch", issues[0].Rule)
+	assert.Equal(t, Error, issues[0].Severity)
+	assert.Equal(t, Position{Item: 1, Line: 8}, issues[0].Position)
+	assert.Equal(t, "download https://example.com/app_2.0.0.dmg length 100000 doesn't match the Content-Length 200000", issues[0].Message)
+	assert.Equal(t, "Set the download length to 200000", issues[0].Fix)
+}
+
+func TestCheckNonMonotonicDates(t *testing.T) {
+	// ordered
+	c := newTestContext(
+		newTestRelease("1.1.0", 12),
+		newTestRelease("1.0.1", 11),
+		newTestRelease("1.0.0", 10),
+	)
+	assert.Len(t, checkNonMonotonicDates(c), 0)
+
+	// backport
+	c = newTestContext(
+		newTestRelease("1.1.0", 12),
+		newTestRelease("1.0.1", 13),
+		newTestRelease("1.0.0", 10),
+	)
+	assert.Len(t, checkNonMonotonicDates(c), 0)
+
+	// non-monotonic
+	c = newTestContext(
+		newTestRelease("1.0.2", 12),
+		newTestRelease("1.0.1", 13),
+		newTestRelease("1.0.0", 10),
+	)
+
+	issues := checkNonMonotonicDates(c)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "release 1.0.1 is published after the newer release 1.0.2", issues[0].Message)
+}
+
+func TestCheckMissingSignature(t *testing.T) {
+	// preparations
+	dsa := newTestRelease("1.0.0", 10)
+
+	ed := newTestRelease("1.1.0", 11)
+	d := release.NewDownload("https://example.com/app_1.1.0.dmg")
+	d.SetEdSignature("xK1n4e9CXXLFjqLUZ3xWT6eNPxV1b2+3LPEkkLNyRMHdYNOqZ2f0cE7Cq1iT7mSd8XvIxPFQFS3tLV5dEmRxBQ==")
+	ed.SetDownloads([]release.Download{*d})
+
+	unsigned := newTestRelease("1.2.0", 12)
+	unsigned.SetDownloads([]release.Download{*release.NewDownload("https://example.com/app_1.2.0.dmg")})
+
+	c := newTestContext(dsa, ed, unsigned)
+
+	// test
+	issues := checkMissingSignature(c)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "download https://example.com/app_1.2.0.dmg has no signature", issues[0].Message)
+	assert.Contains(t, issues[0].Fix, "sparkle:edSignature")
+}
+
+func TestCheckFuturePubDate(t *testing.T) {
+	// preparations
+	defer func() { Now = time.Now }()
+	Now = func() time.Time { return time.Date(2016, 5, 12, 0, 0, 0, 0, time.UTC) }
+
+	c := newTestContext(
+		newTestRelease("1.1.0", 13),
+		newTestRelease("1.0.0", 11),
+	)
+
+	// test
+	issues := checkFuturePubDate(c)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "release 1.1.0 is published in the future: Fri, 13 May 2016 12:00:00 +0000", issues[0].Message)
+}
+
+func TestCheckInsecureUrl(t *testing.T) {
+	// preparations
+	r := newTestRelease("1.0.0", 10)
+	r.SetDownloads([]release.Download{
+		*release.NewDownload("HTTP://example.com/app.dmg", "application/octet-stream", 100000),
+	})
+
+	// test
+	issues := checkInsecureUrl(newTestContext(r))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "Use the HTTPS URL: https://example.com/app.dmg", issues[0].Fix)
+}
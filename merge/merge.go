@@ -0,0 +1,414 @@
+// Package merge combines the releases of the same product tracked through
+// several sources (for example, its Sparkle appcast, GitHub releases and
+// SourceForge mirror) into a single de-duplicated list.
+//
+// Releases are matched by their normalized versions (or builds, when there is
+// no version). Each field of the merged release is taken from the most
+// authoritative source having a non-empty value and the source name is
+// recorded as the field provenance. Downloads are merged by their URLs.
+package merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// field represents a single mergeable release field.
+type field struct {
+	// name specifies the field name. The same names as in diff.FieldChange
+	// are used.
+	name string
+
+	// present checks whether the provided release has the field value.
+	present func(r release.Releaser) bool
+
+	// copy copies the field value from the provided release into the merged
+	// one.
+	copy func(dst *release.Release, src release.Releaser)
+}
+
+// fields holds all the mergeable release fields except the downloads.
+var fields = []field{
+	{
+		"version",
+		func(r release.Releaser) bool { return r.Version() != nil },
+		func(dst *release.Release, src release.Releaser) { dst.SetVersion(src.Version()) },
+	},
+	{
+		"build",
+		func(r release.Releaser) bool { return r.Build() != "" },
+		func(dst *release.Release, src release.Releaser) { dst.SetBuild(src.Build()) },
+	},
+	{
+		"title",
+		func(r release.Releaser) bool { return r.Title() != "" },
+		func(dst *release.Release, src release.Releaser) { dst.SetTitle(src.Title()) },
+	},
+	{
+		"description",
+		func(r release.Releaser) bool { return r.Description() != "" },
+		func(dst *release.Release, src release.Releaser) { dst.SetDescription(src.Description()) },
+	},
+	{
+		"publishedDateTime",
+		func(r release.Releaser) bool {
+			return r.PublishedDateTime() != nil && r.PublishedDateTime().Time() != nil
+		},
+		func(dst *release.Release, src release.Releaser) { dst.SetPublishedDateTime(src.PublishedDateTime()) },
+	},
+	{
+		"releaseNotesLink",
+		func(r release.Releaser) bool { return r.ReleaseNotesLink() != "" },
+		func(dst *release.Release, src release.Releaser) { dst.SetReleaseNotesLink(src.ReleaseNotesLink()) },
+	},
+	{
+		"minimumSystemVersion",
+		func(r release.Releaser) bool { return r.MinimumSystemVersion() != "" },
+		func(dst *release.Release, src release.Releaser) {
+			dst.SetMinimumSystemVersion(src.MinimumSystemVersion())
+		},
+	},
+	{
+		"maximumSystemVersion",
+		func(r release.Releaser) bool { return r.MaximumSystemVersion() != "" },
+		func(dst *release.Release, src release.Releaser) {
+			dst.SetMaximumSystemVersion(src.MaximumSystemVersion())
+		},
+	},
+	{
+		"isPreRelease",
+		func(r release.Releaser) bool { return true },
+		func(dst *release.Release, src release.Releaser) { dst.SetIsPreRelease(src.IsPreRelease()) },
+	},
+}
+
+// downloadsField specifies the field name used for the downloads precedence.
+const downloadsField = "downloads"
+
+// Release represents a single merged release. It embeds the release.Release,
+// so it satisfies the release.Releaser interface, and additionally holds the
+// provenance of each field.
+type Release struct {
+	*release.Release
+
+	// sources specify the names of all sources the release has been found in.
+	sources []string
+
+	// provenance specifies the source name of each non-empty field. The
+	// download fields are prefixed with their index, for example:
+	// "downloads[0].sha256".
+	provenance map[string]string
+}
+
+// Sources is a Release.sources getter.
+func (r *Release) Sources() []string {
+	return r.sources
+}
+
+// Provenance returns the name of the source the provided field value has been
+// taken from. Returns an empty string, if the field is empty.
+func (r *Release) Provenance(field string) string {
+	return r.provenance[field]
+}
+
+// ProvenanceMap returns a copy of the provenance of all non-empty fields.
+func (r *Release) ProvenanceMap() map[string]string {
+	result := make(map[string]string, len(r.provenance))
+	for k, v := range r.provenance {
+		result[k] = v
+	}
+
+	return result
+}
+
+// source represents a single named releases source.
+type source struct {
+	name     string
+	releases release.Releaseser
+}
+
+// candidate represents a single release found in the source.
+type candidate struct {
+	source  int
+	release release.Releaser
+}
+
+// Merger represents the releases merger.
+type Merger struct {
+	sources    []source
+	precedence map[string][]int
+}
+
+// New returns a new Merger instance pointer.
+func New() *Merger {
+	return &Merger{
+		precedence: make(map[string][]int),
+	}
+}
+
+// Add adds the named releases source. The order in which the sources are added
+// defines the default precedence: the first source is the most authoritative
+// one. Returns an error, if the name is empty or has been already added.
+func (m *Merger) Add(name string, releases release.Releaseser) error {
+	if name == "" {
+		return fmt.Errorf("no source name")
+	}
+
+	for _, s := range m.sources {
+		if s.name == name {
+			return fmt.Errorf("duplicate source: %s", name)
+		}
+	}
+
+	m.sources = append(m.sources, source{name: name, releases: releases})
+
+	return nil
+}
+
+// SetPrecedence overrides the default precedence for the provided field by the
+// provided source names starting from the most authoritative one. The sources
+// not listed are used afterwards in their default order.
+//
+// The supported fields are: "version", "build", "title", "description",
+// "publishedDateTime", "releaseNotesLink", "minimumSystemVersion",
+// "maximumSystemVersion", "isPreRelease" and "downloads". The "downloads"
+// precedence defines both the downloads order and the source of their fields.
+//
+// Returns an error, if the field is not supported or the source hasn't been
+// added.
+func (m *Merger) SetPrecedence(field string, names ...string) error {
+	if !isField(field) {
+		return fmt.Errorf("unknown field: %s", field)
+	}
+
+	var order []int
+
+	for _, name := range names {
+		i := m.index(name)
+		if i < 0 {
+			return fmt.Errorf("unknown source: %s", name)
+		}
+
+		order = append(order, i)
+	}
+
+	m.precedence[field] = order
+
+	return nil
+}
+
+// isField checks whether the provided field name is supported.
+func isField(name string) bool {
+	if name == downloadsField {
+		return true
+	}
+
+	for _, f := range fields {
+		if f.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// index returns the index of the source with the provided name. Returns -1, if
+// there is no such source.
+func (m *Merger) index(name string) int {
+	for i, s := range m.sources {
+		if s.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// order returns the source indices ordered by the provided field precedence.
+func (m *Merger) order(field string) []int {
+	result := append([]int{}, m.precedence[field]...)
+
+	seen := make(map[int]bool)
+	for _, i := range result {
+		seen[i] = true
+	}
+
+	for i := range m.sources {
+		if !seen[i] {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// Merge merges the filtered releases of all sources and returns the merged
+// releases sorted by versions in the descending order. Each merged release is
+// a Release instance pointer.
+func (m *Merger) Merge() release.Releaseser {
+	var keys []string
+	groups := make(map[string][]candidate)
+
+	for i, s := range m.sources {
+		if s.releases == nil {
+			continue
+		}
+
+		for j, r := range s.releases.Filtered() {
+			key := Key(r)
+			if key == "" {
+				key = fmt.Sprintf("%s #%d", s.name, j+1)
+			}
+
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+
+			groups[key] = append(groups[key], candidate{source: i, release: r})
+		}
+	}
+
+	var items []release.Releaser
+	for _, key := range keys {
+		items = append(items, m.merge(groups[key]))
+	}
+
+	releases := release.NewReleases(items)
+	releases.SortByVersions(release.DESC)
+
+	return releases
+}
+
+// merge merges the provided candidates of a single release.
+func (m *Merger) merge(candidates []candidate) *Release {
+	r := &Release{
+		Release:    &release.Release{},
+		provenance: make(map[string]string),
+	}
+
+	r.SetPublishedDateTime(release.NewPublishedDateTime())
+
+	for _, i := range m.order("") {
+		for _, c := range candidates {
+			if c.source == i {
+				r.sources = append(r.sources, m.sources[i].name)
+				break
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if c := m.pick(f.name, candidates, f.present); c != nil {
+			f.copy(r.Release, c.release)
+			r.provenance[f.name] = m.sources[c.source].name
+		}
+	}
+
+	m.mergeDownloads(r, candidates)
+
+	return r
+}
+
+// pick returns the most authoritative candidate for the provided field having
+// its value present. Returns nil, if there is no such candidate.
+func (m *Merger) pick(field string, candidates []candidate, present func(r release.Releaser) bool) *candidate {
+	for _, i := range m.order(field) {
+		for j, c := range candidates {
+			if c.source == i && present(c.release) {
+				return &candidates[j]
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeDownloads merges the downloads of the provided candidates into the
+// provided merged release. Downloads are matched by their URLs and their empty
+// fields are filled from the less authoritative sources.
+func (m *Merger) mergeDownloads(r *Release, candidates []candidate) {
+	var downloads []release.Download
+	byUrl := make(map[string]int)
+
+	for _, i := range m.order(downloadsField) {
+		name := m.sources[i].name
+
+		for _, c := range candidates {
+			if c.source != i {
+				continue
+			}
+
+			for _, d := range c.release.Downloads() {
+				url := strings.TrimSpace(d.Url())
+
+				j, ok := byUrl[url]
+				if !ok {
+					j = len(downloads)
+					byUrl[url] = j
+					downloads = append(downloads, *release.NewDownload(url))
+					r.provenance[fmt.Sprintf("downloads[%d].url", j)] = name
+				}
+
+				fillDownload(&downloads[j], d, func(field string) {
+					r.provenance[fmt.Sprintf("downloads[%d].%s", j, field)] = name
+				})
+			}
+		}
+	}
+
+	r.SetDownloads(downloads)
+}
+
+// fillDownload fills the empty fields of the provided dst download from the
+// provided src one and calls the provided filled callback for each filled
+// field.
+func fillDownload(dst *release.Download, src release.Download, filled func(field string)) {
+	if dst.Filetype() == "" && src.Filetype() != "" {
+		dst.SetFiletype(src.Filetype())
+		filled("filetype")
+	}
+
+	if dst.Length() == 0 && src.Length() != 0 {
+		dst.SetLength(src.Length())
+		filled("length")
+	}
+
+	if dst.DsaSignature() == "" && src.DsaSignature() != "" {
+		dst.SetDsaSignature(src.DsaSignature())
+		filled("dsaSignature")
+	}
+
+	if dst.EdSignature() == "" && src.EdSignature() != "" {
+		dst.SetEdSignature(src.EdSignature())
+		filled("edSignature")
+	}
+
+	if dst.Md5() == "" && src.Md5() != "" {
+		dst.SetMd5(src.Md5())
+		filled("md5")
+	}
+
+	if dst.Sha256() == "" && src.Sha256() != "" {
+		dst.SetSha256(src.Sha256())
+		filled("sha256")
+	}
+
+	if dst.ExtraInfo() == "" && src.ExtraInfo() != "" {
+		dst.SetExtraInfo(src.ExtraInfo())
+		filled("extraInfo")
+	}
+}
+
+// Key returns the normalized release identity used for matching the releases
+// from different sources: the normalized semantic version (for example, both
+// "v1.0" and "1.0.0" become "1.0.0") or the lowercased build, when there is no
+// version. Returns an empty string, if there is neither.
+func Key(r release.Releaser) string {
+	if r.Version() != nil {
+		return r.Version().String()
+	}
+
+	return strings.ToLower(strings.TrimSpace(r.Build()))
+}
@@ -0,0 +1,187 @@
+package merge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// newTestRelease creates a new release.Release instance for testing purposes
+// and returns its pointer.
+func newTestRelease(version string, build string, downloads ...*release.Download) *release.Release {
+	r, _ := release.NewLenient(version, build)
+	r.SetPublishedDateTime(release.NewPublishedDateTime())
+
+	for _, d := range downloads {
+		r.AddDownload(*d)
+	}
+
+	return r
+}
+
+// newTestMerger creates a new Merger instance for testing purposes with the
+// "sparkle", "github" and "sourceforge" sources and returns its pointer.
+func newTestMerger() *Merger {
+	t := time.Date(2016, 5, 13, 12, 0, 0, 0, time.UTC)
+
+	// sparkle
+	s1 := newTestRelease("2.0.0", "200", release.NewDownload("https://example.com/app_2.0.0.dmg", "application/octet-stream", 100000, "dsa"))
+	s1.SetTitle("Release 2.0.0")
+	s1.SetMinimumSystemVersion("10.9")
+
+	s2 := newTestRelease("1.0", "100", release.NewDownload("https://example.com/app_1.0.0.dmg"))
+
+	// github
+	g1 := newTestRelease("v2.0.0", "", release.NewDownload("https://github.com/example/app/releases/download/v2.0.0/app.zip"))
+	g1.SetTitle("v2.0.0")
+	g1.SetDescription("Release 2.0.0 Description")
+	g1.SetPublishedDateTime(release.NewPublishedDateTime(&t))
+	g1.SetReleaseNotesLink("https://github.com/example/app/releases/tag/v2.0.0")
+
+	g2 := newTestRelease("2.1.0-beta", "")
+	g2.SetIsPreRelease(true)
+
+	// sourceforge
+	f1 := newTestRelease("2.0.0", "", release.NewDownload("https://example.com/app_2.0.0.dmg", "", 0, "", "", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"))
+	f1.SetTitle("/app/2.0.0/app_2.0.0.dmg")
+
+	m := New()
+	m.Add("sparkle", release.NewReleases([]release.Releaser{s1, s2}))
+	m.Add("github", release.NewReleases([]release.Releaser{g2, g1}))
+	m.Add("sourceforge", release.NewReleases([]release.Releaser{f1}))
+
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := New()
+	assert.IsType(t, &Merger{}, m)
+	assert.Len(t, m.sources, 0)
+	assert.NotNil(t, m.precedence)
+}
+
+func TestMerger_Add(t *testing.T) {
+	// preparations
+	m := New()
+
+	// test (successful)
+	assert.Nil(t, m.Add("sparkle", release.NewReleases(nil)))
+	assert.Len(t, m.sources, 1)
+
+	// test (error)
+	assert.EqualError(t, m.Add("sparkle", nil), "duplicate source: sparkle")
+	assert.EqualError(t, m.Add("", nil), "no source name")
+	assert.Len(t, m.sources, 1)
+}
+
+func TestMerger_SetPrecedence(t *testing.T) {
+	// preparations
+	m := newTestMerger()
+
+	// test (successful)
+	assert.Nil(t, m.SetPrecedence("title", "github"))
+	assert.Equal(t, []int{1, 0, 2}, m.order("title"))
+	assert.Equal(t, []int{0, 1, 2}, m.order("description"))
+
+	assert.Nil(t, m.SetPrecedence("downloads", "sourceforge", "github"))
+	assert.Equal(t, []int{2, 1, 0}, m.order("downloads"))
+
+	// test (error)
+	assert.EqualError(t, m.SetPrecedence("invalid", "github"), "unknown field: invalid")
+	assert.EqualError(t, m.SetPrecedence("title", "invalid"), "unknown source: invalid")
+}
+
+func TestMerger_Merge(t *testing.T) {
+	// preparations
+	m := newTestMerger()
+
+	// test
+	releases := m.Merge()
+	assert.Equal(t, 3, releases.Len())
+
+	r := releases.Filtered()[1].(*Release)
+	assert.Equal(t, "2.0.0", r.Version().Original())
+	assert.Equal(t, "200", r.Build())
+	assert.Equal(t, "Release 2.0.0", r.Title())
+	assert.Equal(t, "Release 2.0.0 Description", r.Description())
+	assert.Equal(t, "2016-05-13 12:00:00 +0000 UTC", r.PublishedDateTime().Time().String())
+	assert.Equal(t, "10.9", r.MinimumSystemVersion())
+	assert.False(t, r.IsPreRelease())
+	assert.Equal(t, []string{"sparkle", "github", "sourceforge"}, r.Sources())
+
+	assert.Equal(t, "sparkle", r.Provenance("version"))
+	assert.Equal(t, "sparkle", r.Provenance("title"))
+	assert.Equal(t, "github", r.Provenance("description"))
+	assert.Equal(t, "github", r.Provenance("publishedDateTime"))
+	assert.Equal(t, "", r.Provenance("maximumSystemVersion"))
+
+	// test (downloads)
+	d := r.Downloads()
+	assert.Len(t, d, 2)
+	assert.Equal(t, "https://example.com/app_2.0.0.dmg", d[0].Url())
+	assert.Equal(t, "dsa", d[0].DsaSignature())
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", d[0].Sha256())
+	assert.Equal(t, "https://github.com/example/app/releases/download/v2.0.0/app.zip", d[1].Url())
+	assert.Equal(t, "sparkle", r.Provenance("downloads[0].url"))
+	assert.Equal(t, "sparkle", r.Provenance("downloads[0].dsaSignature"))
+	assert.Equal(t, "sourceforge", r.Provenance("downloads[0].sha256"))
+	assert.Equal(t, "github", r.Provenance("downloads[1].url"))
+
+	// test (normalized version)
+	r = releases.Filtered()[2].(*Release)
+	assert.Equal(t, "1.0", r.Version().Original())
+	assert.Equal(t, []string{"sparkle"}, r.Sources())
+
+	// test (prerelease)
+	r = releases.First().(*Release)
+	assert.Equal(t, "2.1.0-beta", r.Version().Original())
+	assert.True(t, r.IsPreRelease())
+	assert.Equal(t, "github", r.Provenance("isPreRelease"))
+
+	// test (precedence)
+	m.SetPrecedence("title", "sourceforge", "github")
+	m.SetPrecedence("version", "github")
+	m.SetPrecedence("downloads", "github")
+
+	r = m.Merge().Filtered()[1].(*Release)
+	assert.Equal(t, "v2.0.0", r.Version().Original())
+	assert.Equal(t, "/app/2.0.0/app_2.0.0.dmg", r.Title())
+	assert.Equal(t, "sourceforge", r.Provenance("title"))
+	assert.Equal(t, "https://github.com/example/app/releases/download/v2.0.0/app.zip", r.Downloads()[0].Url())
+	assert.Equal(t, "sourceforge", r.Provenance("downloads[1].sha256"))
+
+	// test (without version and build)
+	m = New()
+	m.Add("sparkle", release.NewReleases([]release.Releaser{newTestRelease("", ""), newTestRelease("", "")}))
+	m.Add("github", nil)
+	assert.Equal(t, 2, m.Merge().Len())
+}
+
+func TestRelease_ProvenanceMap(t *testing.T) {
+	// preparations
+	r := newTestMerger().Merge().First().(*Release)
+
+	// test
+	p := r.ProvenanceMap()
+	assert.Equal(t, "github", p["version"])
+
+	p["version"] = "sparkle"
+	assert.Equal(t, "github", r.Provenance("version"))
+}
+
+func TestKey(t *testing.T) {
+	testCases := map[string][]string{
+		"1.0.0":     {"1.0", ""},
+		"2.0.0-rc1": {"v2.0.0-rc1", "200"},
+		"abc100":    {"invalid", " ABC100 "},
+		"":          {"invalid", ""},
+	}
+
+	for expected, data := range testCases {
+		r, _ := release.NewLenient(data[0], data[1])
+		assert.Equal(t, expected, Key(r))
+	}
+}
@@ -0,0 +1,364 @@
+// Package homebrew adds support for generating the Homebrew Cask stanza from
+// an appcast.
+package homebrew
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/client"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/provider/github"
+	"github.com/victorpopkov/go-appcast/provider/sourceforge"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// DefaultClient is the default Client that is used for downloading the
+// release artifacts in the homebrew package.
+var DefaultClient = source.DefaultClient
+
+// Casker is the interface that wraps the Cask methods.
+type Casker interface {
+	GenerateSha256() error
+	Marshal() ([]byte, error)
+	Version() string
+	SetVersion(version string)
+	Build() string
+	SetBuild(build string)
+	Sha256() string
+	SetSha256(sha256 string)
+	Url() string
+	SetUrl(url string)
+	AppcastUrl() string
+	SetAppcastUrl(appcastUrl string)
+	Strategy() string
+	SetStrategy(strategy string)
+}
+
+// Cask represents the Homebrew Cask stanza generated from a single appcast
+// release.
+type Cask struct {
+	// version specifies the release version that will be used as a cask
+	// "version" value.
+	version string
+
+	// build specifies the release build. When it differs from the
+	// Cask.version and is a part of the Cask.url, both values are joined into
+	// the comma-separated cask "version".
+	build string
+
+	// sha256 specifies the SHA256 checksum of the downloaded artifact. When
+	// it's empty, the ":no_check" is used instead.
+	sha256 string
+
+	// url specifies the release artifact URL.
+	url string
+
+	// appcastUrl specifies the appcast URL that will be used inside the
+	// "livecheck" block. When it's empty, the block is omitted.
+	appcastUrl string
+
+	// strategy specifies the "livecheck" strategy name without the leading
+	// colon. When it's empty, the strategy is omitted.
+	strategy string
+}
+
+// NewCask returns a new Cask instance pointer created from the first release
+// of the provided appcast. Supports the appcasts of all supported providers.
+func NewCask(a appcaster.Appcaster) (*Cask, error) {
+	if a == nil || a.Releases() == nil || a.Releases().Len() == 0 {
+		return nil, fmt.Errorf("no releases")
+	}
+
+	r := a.FirstRelease()
+	if len(r.Downloads()) == 0 {
+		return nil, fmt.Errorf("no downloads")
+	}
+
+	c := &Cask{
+		build: r.Build(),
+		url:   r.Downloads()[0].Url(),
+	}
+
+	if r.Version() != nil {
+		c.version = r.Version().Original()
+	} else {
+		c.version = r.VersionOrBuildString()
+	}
+
+	if src, ok := a.Source().(*source.Remote); ok {
+		c.appcastUrl = src.Url()
+	}
+
+	c.strategy = guessStrategy(a)
+
+	return c, nil
+}
+
+// guessStrategy guesses the "livecheck" strategy name from the provided
+// provider-specific appcast or from its source provider.
+func guessStrategy(a appcaster.Appcaster) string {
+	var p appcaster.Providerer
+
+	switch a.(type) {
+	case *sparkle.Appcast:
+		p = provider.Sparkle
+	case *sourceforge.Appcast:
+		p = provider.SourceForge
+	case *github.Appcast:
+		p = provider.GitHub
+	default:
+		if a.Source() != nil {
+			p = a.Source().Provider()
+		}
+	}
+
+	switch p {
+	case provider.Sparkle:
+		return "sparkle"
+	case provider.SourceForge:
+		return "sourceforge"
+	case provider.GitHub:
+		return "github_latest"
+	}
+
+	return ""
+}
+
+// GenerateSha256 downloads the artifact from the Cask.url using the
+// DefaultClient and sets its SHA256 checksum as a Cask.sha256.
+func (c *Cask) GenerateSha256() error {
+	req, err := client.NewRequest(c.url)
+	if err != nil {
+		return err
+	}
+
+	resp, err := DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("artifact download failed (%s)", resp.Status)
+	}
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, resp.Body)
+	if err != nil {
+		return err
+	}
+
+	c.sha256 = hex.EncodeToString(hasher.Sum(nil))
+
+	return nil
+}
+
+// Marshal marshals the Cask into the Homebrew Cask stanza consisting of the
+// "version", "sha256", "url" and "livecheck" lines. The stanza is indented
+// to be placed directly inside the cask block.
+func (c *Cask) Marshal() ([]byte, error) {
+	if c.version == "" {
+		return nil, fmt.Errorf("no version")
+	}
+
+	if c.url == "" {
+		return nil, fmt.Errorf("no url")
+	}
+
+	var buf bytes.Buffer
+
+	version, url := c.interpolate()
+
+	buf.WriteString(fmt.Sprintf("  version %s\n", quote(version)))
+	if c.sha256 == "" {
+		buf.WriteString("  sha256 :no_check\n")
+	} else {
+		buf.WriteString(fmt.Sprintf("  sha256 %s\n", quote(c.sha256)))
+	}
+
+	buf.WriteString(fmt.Sprintf("\n  url \"%s\"\n", url))
+
+	if c.appcastUrl != "" {
+		buf.WriteString("\n  livecheck do\n")
+		buf.WriteString(fmt.Sprintf("    url %s\n", quote(c.appcastUrl)))
+		if c.strategy != "" {
+			buf.WriteString(fmt.Sprintf("    strategy :%s\n", c.strategy))
+		}
+		buf.WriteString("  end\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// interpolate returns the cask "version" value alongside with the escaped
+// Cask.url where the version (and build) occurrences are replaced by the Ruby
+// interpolation. See replaceVersion.
+func (c *Cask) interpolate() (version string, url string) {
+	url = escape(c.url)
+
+	if c.build != "" && c.build != c.version && replaceVersion(url, escape(c.build), "") != url {
+		version = c.version + "," + c.build
+
+		// replace the longest value first to preserve the shorter one if it's a
+		// part of the longer
+		first, second := "#{version.csv.first}", "#{version.csv.second}"
+		if len(c.build) > len(c.version) {
+			url = replaceVersion(url, escape(c.build), second)
+			url = replaceVersion(url, escape(c.version), first)
+		} else {
+			url = replaceVersion(url, escape(c.version), first)
+			url = replaceVersion(url, escape(c.build), second)
+		}
+
+		return version, url
+	}
+
+	return c.version, replaceVersion(url, escape(c.version), "#{version}")
+}
+
+// replaceVersion replaces the occurrences of the provided version in the
+// provided URL by the provided replacement. The host is kept as is and only
+// the occurrences which aren't a part of a longer number are replaced, so
+// neither "cdn3.example.com" nor "app_1.2.3.dmg" match the "3" or "1.2"
+// versions.
+func replaceVersion(url string, version string, replacement string) string {
+	if version == "" {
+		return url
+	}
+
+	start := 0
+	if i := strings.Index(url, "://"); i >= 0 {
+		start = len(url)
+		if j := strings.Index(url[i+3:], "/"); j >= 0 {
+			start = i + 3 + j
+		}
+	}
+
+	result := url[:start]
+
+	for rest := url[start:]; ; {
+		i := strings.Index(rest, version)
+		if i < 0 {
+			return result + rest
+		}
+
+		before, after := rest[:i], rest[i+len(version):]
+		if isNumberBoundary(before, after) {
+			result += before + replacement
+		} else {
+			result += before + version
+		}
+
+		rest = after
+	}
+}
+
+// reEscapedOctet matches the URL percent-encoded octet at the end of a string.
+var reEscapedOctet = regexp.MustCompile(`%[0-9A-Fa-f]{2}$`)
+
+// isNumberBoundary checks whether the value placed between the provided parts
+// isn't a part of a longer number. The percent-encoded octets, like "%20", are
+// considered to be boundaries.
+func isNumberBoundary(before string, after string) bool {
+	if n := len(before); n > 0 && !reEscapedOctet.MatchString(before) {
+		if isDigit(before[n-1]) || (n > 1 && before[n-1] == '.' && isDigit(before[n-2])) {
+			return false
+		}
+	}
+
+	if n := len(after); n > 0 {
+		if isDigit(after[0]) || (n > 1 && after[0] == '.' && isDigit(after[1])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDigit checks whether the provided byte is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// escape escapes the provided value to be used inside a double-quoted Ruby
+// string literal, including the "#{" interpolation sequence.
+func escape(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	s = strings.Replace(s, `#{`, `\#{`, -1)
+
+	return s
+}
+
+// quote returns a double-quoted Ruby string literal. See escape.
+func quote(s string) string {
+	return `"` + escape(s) + `"`
+}
+
+// Version is a Cask.version getter.
+func (c *Cask) Version() string {
+	return c.version
+}
+
+// SetVersion is a Cask.version setter.
+func (c *Cask) SetVersion(version string) {
+	c.version = version
+}
+
+// Build is a Cask.build getter.
+func (c *Cask) Build() string {
+	return c.build
+}
+
+// SetBuild is a Cask.build setter.
+func (c *Cask) SetBuild(build string) {
+	c.build = build
+}
+
+// Sha256 is a Cask.sha256 getter.
+func (c *Cask) Sha256() string {
+	return c.sha256
+}
+
+// SetSha256 is a Cask.sha256 setter.
+func (c *Cask) SetSha256(sha256 string) {
+	c.sha256 = sha256
+}
+
+// Url is a Cask.url getter.
+func (c *Cask) Url() string {
+	return c.url
+}
+
+// SetUrl is a Cask.url setter.
+func (c *Cask) SetUrl(url string) {
+	c.url = url
+}
+
+// AppcastUrl is a Cask.appcastUrl getter.
+func (c *Cask) AppcastUrl() string {
+	return c.appcastUrl
+}
+
+// SetAppcastUrl is a Cask.appcastUrl setter.
+func (c *Cask) SetAppcastUrl(appcastUrl string) {
+	c.appcastUrl = appcastUrl
+}
+
+// Strategy is a Cask.strategy getter.
+func (c *Cask) Strategy() string {
+	return c.strategy
+}
+
+// SetStrategy is a Cask.strategy setter.
+func (c *Cask) SetStrategy(strategy string) {
+	c.strategy = strategy
+}
@@ -0,0 +1,294 @@
+package homebrew
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/provider"
+	"github.com/victorpopkov/go-appcast/provider/github"
+	"github.com/victorpopkov/go-appcast/provider/sparkle"
+	"github.com/victorpopkov/go-appcast/release"
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// newTestCask creates a new Cask instance for testing purposes and returns its
+// pointer.
+func newTestCask() *Cask {
+	return &Cask{
+		version:    "1.5.10.4",
+		build:      "1.5.10.4",
+		sha256:     "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		url:        "https://adiumx.cachefly.net/Adium_1.5.10.4.dmg",
+		appcastUrl: "https://www.adium.im/sparkle/appcast-release.xml",
+		strategy:   "sparkle",
+	}
+}
+
+// newTestAppcast creates a new Sparkle Appcast instance for testing purposes
+// with a single release and returns its pointer.
+func newTestAppcast(version string, build string, url string) *sparkle.Appcast {
+	r, err := release.New(version, build)
+	if err != nil {
+		panic(err)
+	}
+
+	r.AddDownload(*release.NewDownload(url, "application/octet-stream", 100000))
+
+	src, _ := source.NewRemote("https://example.com/appcast.xml")
+
+	a := sparkle.New(src)
+	a.SetReleases(release.NewReleases([]release.Releaser{r}))
+
+	return a
+}
+
+func TestNewCask(t *testing.T) {
+	// test (successful)
+	a := newTestAppcast("2.0.0", "200", "https://example.com/app_2.0.0_200.dmg")
+
+	c, err := NewCask(a)
+	assert.Nil(t, err)
+	assert.IsType(t, Cask{}, *c)
+	assert.Equal(t, "2.0.0", c.version)
+	assert.Equal(t, "200", c.build)
+	assert.Equal(t, "https://example.com/app_2.0.0_200.dmg", c.url)
+	assert.Equal(t, "https://example.com/appcast.xml", c.appcastUrl)
+	assert.Equal(t, "sparkle", c.strategy)
+	assert.Empty(t, c.sha256)
+
+	// test (successful) [local source]
+	s := new(appcaster.Source)
+	s.SetProvider(provider.GitHub)
+
+	a.SetSource(s)
+
+	c, err = NewCask(&github.Appcast{Appcast: a.Appcast})
+	assert.Nil(t, err)
+	assert.Empty(t, c.appcastUrl)
+	assert.Equal(t, "github_latest", c.strategy)
+
+	// test (error) [no releases]
+	a.SetReleases(release.NewReleases([]release.Releaser{}))
+
+	c, err = NewCask(a)
+	assert.Nil(t, c)
+	assert.EqualError(t, err, "no releases")
+
+	// test (error) [no downloads]
+	r, _ := release.New("2.0.0", "200")
+	a.SetReleases(release.NewReleases([]release.Releaser{r}))
+
+	c, err = NewCask(a)
+	assert.Nil(t, c)
+	assert.EqualError(t, err, "no downloads")
+}
+
+func TestCask_GenerateSha256(t *testing.T) {
+	// preparations
+	url := "https://example.com/app_2.0.0.dmg"
+
+	httpmock.ActivateNonDefault(DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, "test"))
+	httpmock.RegisterResponder("GET", "https://example.com/missing.dmg", httpmock.NewStringResponder(404, ""))
+	defer httpmock.DeactivateAndReset()
+
+	// test (successful)
+	c := newTestCask()
+	c.sha256 = ""
+	c.url = url
+
+	err := c.GenerateSha256()
+	assert.Nil(t, err)
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", c.sha256)
+
+	// test (error) [status]
+	c = newTestCask()
+	c.url = "https://example.com/missing.dmg"
+
+	err = c.GenerateSha256()
+	assert.EqualError(t, err, "artifact download failed (404)")
+
+	// test (error) [request]
+	c = newTestCask()
+	c.url = "http://192.168.0.%31/"
+
+	err = c.GenerateSha256()
+	assert.Error(t, err)
+}
+
+func TestCask_Marshal(t *testing.T) {
+	testCases := map[string]struct {
+		cask     *Cask
+		expected string
+	}{
+		"default": {
+			cask: newTestCask(),
+			expected: `  version "1.5.10.4"
+  sha256 "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+  url "https://adiumx.cachefly.net/Adium_#{version}.dmg"
+
+  livecheck do
+    url "https://www.adium.im/sparkle/appcast-release.xml"
+    strategy :sparkle
+  end
+`,
+		},
+		"build": {
+			cask: &Cask{
+				version:    "2.0.0",
+				build:      "200",
+				url:        "https://example.com/app_2.0.0_200.dmg",
+				appcastUrl: "https://example.com/appcast.xml",
+			},
+			expected: `  version "2.0.0,200"
+  sha256 :no_check
+
+  url "https://example.com/app_#{version.csv.first}_#{version.csv.second}.dmg"
+
+  livecheck do
+    url "https://example.com/appcast.xml"
+  end
+`,
+		},
+		"boundaries": {
+			cask: &Cask{
+				version: "3",
+				build:   "1.2",
+				url:     "https://cdn3.example.com/v3/app-1.2.3/app_3.dmg",
+			},
+			expected: `  version "3"
+  sha256 :no_check
+
+  url "https://cdn3.example.com/v#{version}/app-1.2.3/app_#{version}.dmg"
+`,
+		},
+		"percent-encoded": {
+			cask: &Cask{
+				version: "88.0",
+				url:     "https://example.com/Firefox%2088.0.dmg",
+			},
+			expected: `  version "88.0"
+  sha256 :no_check
+
+  url "https://example.com/Firefox%20#{version}.dmg"
+`,
+		},
+		"escaped": {
+			cask: &Cask{
+				version: "2.0.0",
+				sha256:  "#{test}",
+				url:     "https://example.com/app_2.0.0.dmg?name=\"#{app}\"",
+			},
+			expected: `  version "2.0.0"
+  sha256 "\#{test}"
+
+  url "https://example.com/app_#{version}.dmg?name=\"\#{app}\""
+`,
+		},
+		"without livecheck": {
+			cask: &Cask{
+				version: "2.0.0",
+				build:   "200",
+				sha256:  "test",
+				url:     "https://example.com/app.dmg",
+			},
+			expected: `  version "2.0.0"
+  sha256 "test"
+
+  url "https://example.com/app.dmg"
+`,
+		},
+	}
+
+	// test (successful)
+	for name, testCase := range testCases {
+		content, err := testCase.cask.Marshal()
+		assert.Nil(t, err, name)
+		assert.Equal(t, testCase.expected, string(content), name)
+	}
+
+	// test (error)
+	c := newTestCask()
+	c.version = ""
+
+	content, err := c.Marshal()
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "no version")
+
+	c = newTestCask()
+	c.url = ""
+
+	content, err = c.Marshal()
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "no url")
+}
+
+func TestCask_Version(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.version, c.Version())
+}
+
+func TestCask_SetVersion(t *testing.T) {
+	c := newTestCask()
+	c.SetVersion("")
+	assert.Empty(t, c.version)
+}
+
+func TestCask_Build(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.build, c.Build())
+}
+
+func TestCask_SetBuild(t *testing.T) {
+	c := newTestCask()
+	c.SetBuild("")
+	assert.Empty(t, c.build)
+}
+
+func TestCask_Sha256(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.sha256, c.Sha256())
+}
+
+func TestCask_SetSha256(t *testing.T) {
+	c := newTestCask()
+	c.SetSha256("")
+	assert.Empty(t, c.sha256)
+}
+
+func TestCask_Url(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.url, c.Url())
+}
+
+func TestCask_SetUrl(t *testing.T) {
+	c := newTestCask()
+	c.SetUrl("")
+	assert.Empty(t, c.url)
+}
+
+func TestCask_AppcastUrl(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.appcastUrl, c.AppcastUrl())
+}
+
+func TestCask_SetAppcastUrl(t *testing.T) {
+	c := newTestCask()
+	c.SetAppcastUrl("")
+	assert.Empty(t, c.appcastUrl)
+}
+
+func TestCask_Strategy(t *testing.T) {
+	c := newTestCask()
+	assert.Equal(t, c.strategy, c.Strategy())
+}
+
+func TestCask_SetStrategy(t *testing.T) {
+	c := newTestCask()
+	c.SetStrategy("")
+	assert.Empty(t, c.strategy)
+}
@@ -0,0 +1,65 @@
+// Package appstream adds support for the AppStream metainfo releases.
+package appstream
+
+import (
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Appcaster is the interface that wraps the Appcast methods.
+type Appcaster interface {
+	appcaster.Appcaster
+	Component() *Component
+	SetComponent(component *Component)
+	Uncomment() error
+}
+
+// Appcast represents the appcast itself.
+type Appcast struct {
+	appcaster.Appcast
+	component *Component
+}
+
+// Component represents the AppStream component which releases are described.
+type Component struct {
+	ID      string
+	Name    string
+	Summary string
+}
+
+// New returns a new Appcast instance pointer. The source can be passed as a
+// parameter.
+func New(src ...interface{}) *Appcast {
+	a := new(Appcast)
+
+	if len(src) > 0 {
+		src := src[0].(appcaster.Sourcer)
+		a.SetSource(src)
+	}
+
+	return a
+}
+
+// Unmarshal unmarshals the Appcast.source.content into the Appcast.releases and
+// Appcast.component.
+//
+// It returns both: the supported provider-specific appcast implementing the
+// Appcaster interface and an errors slice.
+func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
+	return unmarshal(a)
+}
+
+// Uncomment uncomments the commented out releases in Appcast.source.content. The
+// releases of these releases are marked as commented on unmarshalling.
+func (a *Appcast) Uncomment() error {
+	return a.UncommentItems("release")
+}
+
+// Component is an Appcast.component getter.
+func (a *Appcast) Component() *Component {
+	return a.component
+}
+
+// SetComponent is an Appcast.component setter.
+func (a *Appcast) SetComponent(component *Component) {
+	a.component = component
+}
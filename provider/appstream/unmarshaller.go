@@ -0,0 +1,196 @@
+package appstream
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// unmarshalComponent represents an AppStream component itself for the
+// unmarshalling purposes.
+type unmarshalComponent struct {
+	ID       string             `xml:"id"`
+	Name     string             `xml:"name"`
+	Summary  string             `xml:"summary"`
+	Releases []unmarshalRelease `xml:"releases>release"`
+}
+
+// unmarshalRelease represents a single AppStream release for the
+// unmarshalling purposes.
+type unmarshalRelease struct {
+	Version     string                      `xml:"version,attr"`
+	Date        string                      `xml:"date,attr"`
+	Timestamp   string                      `xml:"timestamp,attr"`
+	Type        string                      `xml:"type,attr"`
+	Urls        []unmarshalReleaseUrl       `xml:"url"`
+	Description unmarshalReleaseDescription `xml:"description"`
+	Artifacts   []unmarshalReleaseArtifact  `xml:"artifacts>artifact"`
+}
+
+// unmarshalReleaseUrl represents a single AppStream release URL for the
+// unmarshalling purposes.
+type unmarshalReleaseUrl struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// unmarshalReleaseDescription represents an AppStream release description for
+// the unmarshalling purposes.
+type unmarshalReleaseDescription struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// unmarshalReleaseArtifact represents a single AppStream release artifact for
+// the unmarshalling purposes.
+type unmarshalReleaseArtifact struct {
+	Type      string                             `xml:"type,attr"`
+	Platform  string                             `xml:"platform,attr"`
+	Location  string                             `xml:"location"`
+	Checksums []unmarshalReleaseArtifactChecksum `xml:"checksum"`
+	Sizes     []unmarshalReleaseArtifactSize     `xml:"size"`
+}
+
+// unmarshalReleaseArtifactChecksum represents a single AppStream release
+// artifact checksum for the unmarshalling purposes.
+type unmarshalReleaseArtifactChecksum struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// unmarshalReleaseArtifactSize represents a single AppStream release artifact
+// size for the unmarshalling purposes.
+type unmarshalReleaseArtifactSize struct {
+	Type  string `xml:"type,attr"`
+	Value int    `xml:",chardata"`
+}
+
+// unmarshal unmarshals the Appcast.source.content from the provided Appcast
+// pointer into its Appcast.releases and Appcast.component fields.
+func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
+	var component unmarshalComponent
+	var errors []error
+
+	if a.Source() == nil || len(a.Source().Content()) == 0 {
+		return nil, append(errors, fmt.Errorf("no source"))
+	}
+
+	if a.Source().Appcast() == nil {
+		a.Source().SetAppcast(a)
+	}
+
+	content, repairs, err := a.UnmarshalContent(a.Source().Content(), &component)
+	if err != nil {
+		return nil, append(errors, append(repairs, err)...)
+	}
+
+	r, errs := createReleases(component, a.CommentedItems())
+	errs.Locate(appcaster.LocateElements(content, "release"))
+	errs = append(repairs, errs...)
+
+	a.SetReleases(r)
+
+	a.component = &Component{
+		ID:      component.ID,
+		Name:    component.Name,
+		Summary: component.Summary,
+	}
+
+	return a, errs
+}
+
+// createReleases creates a release.Releaseser slice from the unmarshalled
+// component.
+// The releases of the provided commented items are marked as commented.
+func createReleases(component unmarshalComponent, commented map[int]bool) (release.Releaseser, appcaster.Errors) {
+	var items []release.Releaser
+	var errors appcaster.Errors
+
+	for i, item := range component.Releases {
+		if item.Version == "" {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", "", appcaster.ErrNoVersion))
+			continue
+		}
+
+		// new release (releases with malformed versions are kept with their
+		// version as a build, so they can still be sorted using the
+		// SparkleComparator)
+		r, err := release.NewLenient(item.Version, "")
+		if err != nil {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", item.Version, err))
+			r.SetBuild(item.Version)
+		}
+
+		r.SetTitle(item.Version)
+		r.SetDescription(strings.TrimSpace(item.Description.InnerXML))
+
+		for _, u := range item.Urls {
+			if u.Type == "" || u.Type == "details" {
+				r.SetReleaseNotesLink(strings.TrimSpace(u.Value))
+				break
+			}
+		}
+
+		// publishedDateTime
+		p := release.NewPublishedDateTime()
+
+		if item.Date == "" && item.Timestamp != "" {
+			timestamp, err := strconv.ParseInt(item.Timestamp, 10, 64)
+			if err != nil {
+				errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.Timestamp, fmt.Errorf("parsing of the published datetime failed")))
+			} else {
+				t := time.Unix(timestamp, 0).UTC()
+				p.SetTime(&t)
+				p.SetFormat(time.RFC3339)
+			}
+		} else {
+			err = p.Parse(item.Date)
+			if err != nil {
+				errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.Date, err))
+			}
+		}
+
+		r.SetPublishedDateTime(p)
+
+		// prerelease
+		if item.Type == "development" || (r.Version() != nil && r.Version().Prerelease() != "") {
+			r.SetIsPreRelease(true)
+		}
+
+		// downloads
+		for _, artifact := range item.Artifacts {
+			location := strings.TrimSpace(artifact.Location)
+			if location == "" {
+				continue
+			}
+
+			d := release.NewDownload(location)
+
+			for _, size := range artifact.Sizes {
+				if size.Type == "download" {
+					d.SetLength(size.Value)
+				}
+			}
+
+			for _, checksum := range artifact.Checksums {
+				switch checksum.Type {
+				case "md5":
+					d.SetMd5(strings.TrimSpace(checksum.Value))
+				case "sha256":
+					d.SetSha256(strings.TrimSpace(checksum.Value))
+				}
+			}
+
+			r.AddDownload(*d)
+		}
+
+		// add release
+		r.SetIsCommented(commented[i])
+		items = append(items, r)
+	}
+
+	return release.NewReleases(items), errors
+}
@@ -0,0 +1,435 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// prologueSize specifies the maximum number of content bytes parsed while
+// guessing the provider.
+const prologueSize = 64 * 1024
+
+// The namespaces of the supported providers.
+const (
+	atomNamespace        = "http://www.w3.org/2005/Atom"
+	dataServicesPrefix   = "http://schemas.microsoft.com/ado/2007/08/dataservices"
+	sourceForgeNamespace = "https://sourceforge.net/api/"
+	sparkleNamespace     = "http://www.andymatuschak.org/xml-namespaces/sparkle"
+)
+
+// Candidate represents a provider guessed from the content alongside with the
+// confidence and the reasons behind it.
+type Candidate struct {
+	// Provider specifies the guessed provider.
+	Provider Provider
+
+	// Confidence specifies how confident the guess is. It ranges from 0 to 1.
+	Confidence float64
+
+	// Reasons specifies the signals found in the content, for example:
+	// "declares the Sparkle namespace".
+	Reasons []string
+}
+
+// String returns the string representation of the Candidate.
+func (c *Candidate) String() string {
+	return fmt.Sprintf("%s (%.2f): %s", c.Provider, c.Confidence, strings.Join(c.Reasons, ", "))
+}
+
+// Candidates represents the provider candidates ranked by their confidence.
+type Candidates []*Candidate
+
+// Best returns the provider of the most confident Candidate. By default
+// returns Provider.Unknown.
+func (c Candidates) Best() Provider {
+	if len(c) == 0 {
+		return Unknown
+	}
+
+	return c[0].Provider
+}
+
+// Merge returns new Candidates combining both the Candidates and the provided
+// ones. The same providers are merged into a single Candidate with the highest
+// confidence and the reasons of both. On equal confidence, the Candidates go
+// before the provided ones.
+func (c Candidates) Merge(other Candidates) Candidates {
+	var result Candidates
+
+	byProvider := make(map[Provider]*Candidate)
+
+	for _, candidate := range append(append(Candidates{}, c...), other...) {
+		merged, ok := byProvider[candidate.Provider]
+		if !ok {
+			merged = &Candidate{Provider: candidate.Provider}
+			byProvider[candidate.Provider] = merged
+			result = append(result, merged)
+		}
+
+		merged.Confidence = math.Max(merged.Confidence, candidate.Confidence)
+		merged.Reasons = append(merged.Reasons, candidate.Reasons...)
+	}
+
+	result.sort()
+
+	return result
+}
+
+// sort sorts the Candidates by their confidence in the descending order
+// keeping the order of the equal ones.
+func (c Candidates) sort() {
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].Confidence > c[j].Confidence
+	})
+}
+
+// signals represents the provider signals found in the content.
+type signals map[Provider]*Candidate
+
+// add adds the provided weight and reason to the provider Candidate.
+func (s signals) add(p Provider, weight float64, reason string, a ...interface{}) {
+	c, ok := s[p]
+	if !ok {
+		c = &Candidate{Provider: p}
+		s[p] = c
+	}
+
+	c.Confidence += weight
+	c.Reasons = append(c.Reasons, fmt.Sprintf(reason, a...))
+}
+
+// support adds the provided weight and reason to the provider Candidate only
+// if it already has any signals. It's used for the signals that aren't
+// distinctive on their own, like the root element.
+func (s signals) support(p Provider, weight float64, reason string, a ...interface{}) {
+	if _, ok := s[p]; ok {
+		s.add(p, weight, reason, a...)
+	}
+}
+
+// candidates returns the ranked Candidates of the found signals.
+func (s signals) candidates() Candidates {
+	var result Candidates
+
+	for p := Unknown; int(p) < len(providerNames); p++ {
+		if c, ok := s[p]; ok {
+			c.Confidence = math.Min(1, math.Round(c.Confidence*100)/100)
+			result = append(result, c)
+		}
+	}
+
+	result.sort()
+
+	return result
+}
+
+// GuessProvidersByContent attempts to guess the supported providers from the
+// passed content. Only the prologue is parsed: the root element with its
+// namespaces and the feed header up to the end of the first feed item within
+// the first 64 KiB.
+//
+// Returns the Candidates ranked by their confidence, each with the reasons, so
+// the misdetections can be debugged. The "Generic RSS/Atom Feed" is always the
+// least confident one, as it's used as a fallback. Returns nil, if none of the
+// supported providers match.
+func GuessProvidersByContent(content []byte) Candidates {
+	if len(content) > prologueSize {
+		content = content[:prologueSize]
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return guessJSON(trimmed).candidates()
+	}
+
+	return guessXML(content).candidates()
+}
+
+// guessXML returns the provider signals found in the prologue of the provided
+// XML content.
+func guessXML(content []byte) signals {
+	s := make(signals)
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		// the prologue markup is ASCII in all supported charsets
+		return input, nil
+	}
+
+	var root xml.Name
+	var id string
+
+	namespaces := make(map[string]string)
+	spaces := make(map[string]bool)
+	elements := make(map[string]bool)
+	links := make(map[string]bool)
+
+	depth, itemDepth := 0, 0
+	hasItem, hasEnclosure, inID := false, false, false
+
+scan:
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			if depth == 1 {
+				root = t.Name
+			}
+
+			elements[t.Name.Local] = true
+			spaces[t.Name.Space] = true
+
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "xmlns":
+					namespaces[attr.Name.Local] = attr.Value
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					namespaces[""] = attr.Value
+				default:
+					spaces[attr.Name.Space] = true
+				}
+
+				if t.Name.Local == "link" && attr.Name.Local == "href" {
+					links[attr.Value] = true
+				}
+
+				if t.Name.Local == "link" && attr.Name.Local == "rel" && attr.Value == "enclosure" {
+					hasEnclosure = true
+				}
+			}
+
+			switch {
+			case t.Name.Local == "enclosure":
+				hasEnclosure = true
+			case depth == 2 && t.Name.Local == "id" && id == "":
+				inID = true
+			case depth > 1 && itemDepth == 0 && isItem(root, t.Name):
+				hasItem, itemDepth = true, depth
+			}
+		case xml.EndElement:
+			if depth == itemDepth {
+				break scan
+			}
+
+			inID = false
+			depth--
+		case xml.CharData:
+			if inID {
+				id += string(t)
+			}
+		}
+	}
+
+	// AppStream Metainfo
+	if root.Local == "component" {
+		s.add(AppStream, 0.6, "the root element is \"component\"")
+	}
+
+	if elements["releases"] {
+		s.support(AppStream, 0.3, "has the \"releases\" element")
+	}
+
+	if elements["release"] {
+		s.support(AppStream, 0.1, "has the \"release\" elements")
+	}
+
+	// GitHub Atom Feed
+	if strings.HasPrefix(strings.TrimSpace(id), "tag:github.com,") {
+		s.add(GitHub, 0.6, "the feed ID is a GitHub tag URI")
+	}
+
+	for link := range links {
+		if strings.Contains(link, "github.com/") && strings.HasSuffix(link, "/releases") {
+			s.add(GitHub, 0.2, "links to the GitHub releases")
+			break
+		}
+	}
+
+	// NuGet Feed
+	for _, uri := range namespaces {
+		if strings.HasPrefix(uri, dataServicesPrefix) {
+			s.add(NuGet, 0.6, "declares the OData namespace")
+			break
+		}
+	}
+
+	if elements["properties"] && spaces[dataServicesPrefix+"/metadata"] {
+		s.support(NuGet, 0.3, "has the OData properties")
+	}
+
+	// SourceForge RSS Feed
+	for prefix, uri := range namespaces {
+		if strings.HasPrefix(uri, sourceForgeNamespace) || prefix == "sf" {
+			s.add(SourceForge, 0.6, "declares the SourceForge namespace")
+			break
+		}
+	}
+
+	for space := range spaces {
+		if strings.HasPrefix(space, sourceForgeNamespace) {
+			s.support(SourceForge, 0.3, "has the SourceForge elements")
+			break
+		}
+	}
+
+	// Sparkle RSS Feed
+	if uri, ok := namespaces["sparkle"]; ok {
+		if uri == sparkleNamespace {
+			s.add(Sparkle, 0.5, "declares the Sparkle namespace")
+		} else {
+			s.add(Sparkle, 0.4, "declares the \"sparkle\" prefix with the non-standard namespace")
+		}
+	}
+
+	if spaces[sparkleNamespace] || spaces["sparkle"] || (namespaces["sparkle"] != "" && spaces[namespaces["sparkle"]]) {
+		s.add(Sparkle, 0.4, "has the Sparkle elements or attributes")
+	}
+
+	// Generic RSS/Atom Feed
+	switch {
+	case root.Local == "rss" && hasItem:
+		s.add(Generic, 0.3, "the RSS feed has items")
+	case root.Local == "feed" && hasItem:
+		s.add(Generic, 0.3, "the Atom feed has entries")
+	}
+
+	if hasEnclosure {
+		s.support(Generic, 0.1, "the items have enclosures")
+	}
+
+	// the root element supports the signals of its providers
+	switch {
+	case root.Local == "rss":
+		for _, p := range []Provider{Sparkle, SourceForge} {
+			s.support(p, 0.1, "the root element is \"rss\"")
+		}
+	case root.Local == "feed" && (root.Space == atomNamespace || root.Space == ""):
+		for _, p := range []Provider{GitHub, NuGet} {
+			s.support(p, 0.1, "the root element is the Atom \"feed\"")
+		}
+	}
+
+	return s
+}
+
+// isItem checks whether the provided element name is a feed item of the
+// provided root element.
+func isItem(root xml.Name, name xml.Name) bool {
+	switch root.Local {
+	case "rss":
+		return name.Local == "item"
+	case "feed":
+		return name.Local == "entry"
+	case "component":
+		return name.Local == "release"
+	}
+
+	return false
+}
+
+// jsonFrame represents a single JSON object or array while scanning.
+type jsonFrame struct {
+	object    bool
+	expectKey bool
+	key       string
+}
+
+// guessJSON returns the provider signals found in the prologue of the provided
+// JSON content.
+func guessJSON(content []byte) signals {
+	s := make(signals)
+
+	keys := make(map[string]bool)
+	values := make(map[string][]string)
+
+	var stack []*jsonFrame
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		var top *jsonFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				frame := &jsonFrame{object: t == '{', expectKey: t == '{'}
+				if top != nil {
+					frame.key = top.key
+					if top.object {
+						top.expectKey = true
+					}
+				}
+
+				stack = append(stack, frame)
+			default:
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			if top == nil {
+				continue
+			}
+
+			if top.object && top.expectKey {
+				top.key, top.expectKey = fmt.Sprint(t), false
+				keys[top.key] = true
+				continue
+			}
+
+			// only the top-level values are kept
+			if str, ok := t.(string); ok && (len(stack) == 1 || (len(stack) == 2 && !top.object)) {
+				values[top.key] = append(values[top.key], str)
+			}
+
+			if top.object {
+				top.expectKey = true
+			}
+		}
+	}
+
+	// JSON Feed
+	for _, v := range values["version"] {
+		if strings.HasPrefix(v, "https://jsonfeed.org/version/") || strings.HasPrefix(v, "http://jsonfeed.org/version/") {
+			s.add(JSONFeed, 0.9, "the version is a JSON Feed version URL")
+		}
+	}
+
+	if keys["items"] {
+		s.support(JSONFeed, 0.1, "has the \"items\" key")
+	}
+
+	// NuGet Feed
+	if keys["catalogEntry"] {
+		s.add(NuGet, 0.6, "has the NuGet catalog entries")
+	}
+
+	for _, v := range values["@type"] {
+		if v == "PackageRegistration" || v == "catalog:CatalogRoot" {
+			s.add(NuGet, 0.4, "the type is the NuGet package registration")
+			break
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidate_String(t *testing.T) {
+	c := &Candidate{
+		Provider:   Sparkle,
+		Confidence: 0.5,
+		Reasons:    []string{"declares the Sparkle namespace", "the root element is \"rss\""},
+	}
+
+	assert.Equal(t, "Sparkle RSS Feed (0.50): declares the Sparkle namespace, the root element is \"rss\"", c.String())
+}
+
+func TestCandidates_Best(t *testing.T) {
+	assert.Equal(t, Unknown, Candidates(nil).Best())
+	assert.Equal(t, GitHub, Candidates{{Provider: GitHub, Confidence: 0.9}, {Provider: Generic, Confidence: 0.3}}.Best())
+}
+
+func TestCandidates_Merge(t *testing.T) {
+	// preparations
+	url := Candidates{{Provider: NuGet, Confidence: 1, Reasons: []string{"a"}}}
+	content := Candidates{
+		{Provider: Generic, Confidence: 1, Reasons: []string{"b"}},
+		{Provider: NuGet, Confidence: 0.7, Reasons: []string{"c"}},
+	}
+
+	// test
+	merged := url.Merge(content)
+	assert.Len(t, merged, 2)
+	assert.Equal(t, &Candidate{Provider: NuGet, Confidence: 1, Reasons: []string{"a", "c"}}, merged[0])
+	assert.Equal(t, &Candidate{Provider: Generic, Confidence: 1, Reasons: []string{"b"}}, merged[1])
+	assert.Len(t, url, 1)
+	assert.Equal(t, []string{"a"}, url[0].Reasons)
+
+	assert.Nil(t, Candidates(nil).Merge(nil))
+}
+
+func TestGuessProvidersByContent(t *testing.T) {
+	testCases := map[string][]string{
+		"appstream/testdata/unmarshal/default.xml": {
+			"AppStream Metainfo (1.00): the root element is \"component\", has the \"releases\" element, has the \"release\" elements",
+		},
+		"generic/testdata/unmarshal/rss.xml": {
+			"Generic RSS/Atom Feed (0.40): the RSS feed has items, the items have enclosures",
+		},
+		"github/testdata/unmarshal/default.xml": {
+			"GitHub Atom Feed (0.90): the feed ID is a GitHub tag URI, links to the GitHub releases, the root element is the Atom \"feed\"",
+			"Generic RSS/Atom Feed (0.30): the Atom feed has entries",
+		},
+		"jsonfeed/testdata/unmarshal/default.json": {
+			"JSON Feed (1.00): the version is a JSON Feed version URL, has the \"items\" key",
+		},
+		"nuget/testdata/unmarshal/default.json": {
+			"NuGet Feed (1.00): has the NuGet catalog entries, the type is the NuGet package registration",
+		},
+		"nuget/testdata/unmarshal/default.xml": {
+			"NuGet Feed (1.00): declares the OData namespace, has the OData properties, the root element is the Atom \"feed\"",
+			"Generic RSS/Atom Feed (0.30): the Atom feed has entries",
+		},
+		"sourceforge/testdata/unmarshal/default.xml": {
+			"SourceForge RSS Feed (1.00): declares the SourceForge namespace, has the SourceForge elements, the root element is \"rss\"",
+			"Generic RSS/Atom Feed (0.30): the RSS feed has items",
+		},
+		"sparkle/testdata/unmarshal/default.xml": {
+			"Sparkle RSS Feed (1.00): declares the Sparkle namespace, has the Sparkle elements or attributes, the root element is \"rss\"",
+			"Generic RSS/Atom Feed (0.40): the RSS feed has items, the items have enclosures",
+		},
+		"sparkle/testdata/unmarshal/incorrect_namespace.xml": {
+			"Sparkle RSS Feed (0.90): declares the \"sparkle\" prefix with the non-standard namespace, has the Sparkle elements or attributes, the root element is \"rss\"",
+			"Generic RSS/Atom Feed (0.40): the RSS feed has items, the items have enclosures",
+		},
+		"sparkle/testdata/unmarshal/without_namespaces.xml": {
+			"Sparkle RSS Feed (0.50): has the Sparkle elements or attributes, the root element is \"rss\"",
+			"Generic RSS/Atom Feed (0.40): the RSS feed has items, the items have enclosures",
+		},
+		"../testdata/unknown.xml": nil,
+	}
+
+	// test
+	for filename, expected := range testCases {
+		var result []string
+		for _, c := range GuessProvidersByContent(getTestdata(filename)) {
+			result = append(result, c.String())
+		}
+
+		assert.Equal(t, expected, result, fmt.Sprintf("Candidates don't match: %s", filename))
+	}
+}
+
+func TestGuessProvidersByContent_Prologue(t *testing.T) {
+	// preparations
+	item := `<item><title>2.0.0</title><enclosure url="https://example.com/app_2.0.0.dmg" /></item>`
+	sparkleItem := `<item><enclosure sparkle:version="200" url="https://example.com/app_2.0.0.dmg" /></item>`
+	padding := "<description>" + strings.Repeat("a", prologueSize) + "</description>"
+
+	// test (only the first item is parsed)
+	candidates := GuessProvidersByContent([]byte("<rss><channel>" + item + sparkleItem + "</channel></rss>"))
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, Generic, candidates.Best())
+
+	// test (only the first 64 KiB are parsed)
+	candidates = GuessProvidersByContent([]byte(`<rss xmlns:sparkle="` + sparkleNamespace + `"><channel>` + padding + sparkleItem + "</channel></rss>"))
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, Sparkle, candidates.Best())
+	assert.Equal(t, []string{"declares the Sparkle namespace", "the root element is \"rss\""}, candidates[0].Reasons)
+
+	// test (malformed)
+	assert.Equal(t, Generic, GuessProvidersByContent([]byte("<rss><channel><item></rss>")).Best())
+	assert.Nil(t, GuessProvidersByContent([]byte(`{"version": `)))
+	assert.Nil(t, GuessProvidersByContent([]byte("not an appcast")))
+	assert.Nil(t, GuessProvidersByContent(nil))
+}
@@ -0,0 +1,83 @@
+// Package generic adds a fallback support for the plain RSS 2.0 and Atom
+// releases feeds that don't follow any of the supported providers.
+//
+// As such feeds don't have any dedicated release elements, the versions are
+// extracted from the titles and links. Therefore, the unmarshalling reports a
+// confidence, so the caller can decide whether the result can be trusted.
+package generic
+
+import (
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Appcaster is the interface that wraps the Appcast methods.
+type Appcaster interface {
+	appcaster.Appcaster
+	Channel() *Channel
+	SetChannel(channel *Channel)
+	Confidence() float64
+	Uncomment() error
+}
+
+// Appcast represents the appcast itself.
+type Appcast struct {
+	appcaster.Appcast
+	channel *Channel
+
+	// confidence specifies how confident the unmarshalling is in the extracted
+	// releases. It ranges from 0 (no releases could be extracted) to 1 (each
+	// item has both the version in its title and the download).
+	confidence float64
+}
+
+// Channel represents the appcast channel.
+type Channel struct {
+	Title       string
+	Link        string
+	Description string
+}
+
+// New returns a new Appcast instance pointer. The source can be passed as a
+// parameter.
+func New(src ...interface{}) *Appcast {
+	a := new(Appcast)
+
+	if len(src) > 0 {
+		src := src[0].(appcaster.Sourcer)
+		a.SetSource(src)
+	}
+
+	return a
+}
+
+// Unmarshal unmarshals the Appcast.source.content into the Appcast.releases,
+// Appcast.channel and Appcast.confidence. Both the RSS 2.0 and the Atom feeds
+// are supported.
+//
+// It returns both: the supported provider-specific appcast implementing the
+// Appcaster interface and an errors slice.
+func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
+	return unmarshal(a)
+}
+
+// Uncomment uncomments the commented out RSS items and Atom entries in
+// Appcast.source.content. The releases of these items and entries are marked as
+// commented on unmarshalling.
+func (a *Appcast) Uncomment() error {
+	return a.UncommentItems("item", "entry")
+}
+
+// Channel is an Appcast.channel getter.
+func (a *Appcast) Channel() *Channel {
+	return a.channel
+}
+
+// SetChannel is an Appcast.channel setter.
+func (a *Appcast) SetChannel(channel *Channel) {
+	a.channel = channel
+}
+
+// Confidence is an Appcast.confidence getter.
+func (a *Appcast) Confidence() float64 {
+	return a.confidence
+}
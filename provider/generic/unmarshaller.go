@@ -0,0 +1,317 @@
+package generic
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// unmarshalFeed represents either an RSS or an Atom itself for the
+// unmarshalling purposes.
+type unmarshalFeed struct {
+	XMLName xml.Name
+
+	// RSS
+	Channel unmarshalFeedChannel `xml:"channel"`
+
+	// Atom
+	Title    string               `xml:"title"`
+	Subtitle string               `xml:"subtitle"`
+	Links    []unmarshalFeedLink  `xml:"link"`
+	Entries  []unmarshalFeedEntry `xml:"entry"`
+}
+
+// unmarshalFeedChannel represents an RSS channel for the unmarshalling
+// purposes.
+type unmarshalFeedChannel struct {
+	Title       string              `xml:"title"`
+	Link        string              `xml:"link"`
+	Description string              `xml:"description"`
+	Items       []unmarshalFeedItem `xml:"item"`
+}
+
+// unmarshalFeedItem represents a single RSS item for the unmarshalling
+// purposes.
+type unmarshalFeedItem struct {
+	Title       string                   `xml:"title"`
+	Link        string                   `xml:"link"`
+	Guid        string                   `xml:"guid"`
+	Description string                   `xml:"description"`
+	PubDate     string                   `xml:"pubDate"`
+	Enclosures  []unmarshalFeedEnclosure `xml:"enclosure"`
+}
+
+// unmarshalFeedEnclosure represents a single RSS item enclosure for the
+// unmarshalling purposes.
+type unmarshalFeedEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// unmarshalFeedEntry represents a single Atom entry for the unmarshalling
+// purposes.
+type unmarshalFeedEntry struct {
+	ID        string              `xml:"id"`
+	Title     string              `xml:"title"`
+	Updated   string              `xml:"updated"`
+	Published string              `xml:"published"`
+	Summary   string              `xml:"summary"`
+	Content   string              `xml:"content"`
+	Links     []unmarshalFeedLink `xml:"link"`
+}
+
+// unmarshalFeedLink represents a single Atom link for the unmarshalling
+// purposes.
+type unmarshalFeedLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+// unmarshalRelease represents a single RSS item or Atom entry for the
+// unmarshalling purposes.
+type unmarshalRelease struct {
+	title       string
+	links       []string
+	description string
+	published   string
+	enclosures  []unmarshalFeedEnclosure
+}
+
+// regexFile matches the URLs that point to the commonly distributed files.
+var regexFile = regexp.MustCompile(`(?i)\.(dmg|pkg|mpkg|zip|tar\.gz|tgz|tar\.bz2|tar\.xz|exe|msi|appimage|deb|rpm|7z)(\?.*)?(/download)?$`)
+
+// unmarshal unmarshals the Appcast.source.content from the provided Appcast
+// pointer into its Appcast.releases, Appcast.channel and Appcast.confidence
+// fields.
+func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
+	var feed unmarshalFeed
+	var items []unmarshalRelease
+	var name string
+	var errors []error
+
+	if a.Source() == nil || len(a.Source().Content()) == 0 {
+		return nil, append(errors, fmt.Errorf("no source"))
+	}
+
+	if a.Source().Appcast() == nil {
+		a.Source().SetAppcast(a)
+	}
+
+	content, repairs, err := a.UnmarshalContent(a.Source().Content(), &feed)
+	if err != nil {
+		return nil, append(errors, append(repairs, err)...)
+	}
+
+	switch feed.XMLName.Local {
+	case "rss":
+		items = rssReleases(feed)
+		name = "item"
+		a.channel = &Channel{
+			Title:       feed.Channel.Title,
+			Link:        feed.Channel.Link,
+			Description: feed.Channel.Description,
+		}
+	case "feed":
+		items = atomReleases(feed)
+		name = "entry"
+		a.channel = &Channel{
+			Title:       feed.Title,
+			Link:        alternateLink(feed.Links),
+			Description: feed.Subtitle,
+		}
+	default:
+		return nil, append(errors, append(repairs, fmt.Errorf("unsupported root element <%s>", feed.XMLName.Local))...)
+	}
+
+	r, confidence, errs := createReleases(items, a.CommentedItems())
+	errs.Locate(appcaster.LocateElements(content, name))
+	errs = append(repairs, errs...)
+
+	a.SetReleases(r)
+	a.confidence = confidence
+
+	return a, errs
+}
+
+// rssReleases converts the unmarshalled RSS items into the unmarshalRelease
+// slice.
+func rssReleases(feed unmarshalFeed) []unmarshalRelease {
+	var items []unmarshalRelease
+
+	for _, item := range feed.Channel.Items {
+		items = append(items, unmarshalRelease{
+			title:       item.Title,
+			links:       []string{item.Link, item.Guid},
+			description: item.Description,
+			published:   item.PubDate,
+			enclosures:  item.Enclosures,
+		})
+	}
+
+	return items
+}
+
+// atomReleases converts the unmarshalled Atom entries into the
+// unmarshalRelease slice. The links with the "enclosure" relation are
+// considered to be enclosures.
+func atomReleases(feed unmarshalFeed) []unmarshalRelease {
+	var items []unmarshalRelease
+
+	for _, entry := range feed.Entries {
+		item := unmarshalRelease{
+			title:       entry.Title,
+			description: entry.Content,
+			published:   entry.Published,
+		}
+
+		if item.description == "" {
+			item.description = entry.Summary
+		}
+
+		if item.published == "" {
+			item.published = entry.Updated
+		}
+
+		for _, link := range entry.Links {
+			if link.Rel == "enclosure" {
+				item.enclosures = append(item.enclosures, unmarshalFeedEnclosure{
+					URL:    link.Href,
+					Length: link.Length,
+					Type:   link.Type,
+				})
+				continue
+			}
+
+			item.links = append(item.links, link.Href)
+		}
+
+		item.links = append(item.links, entry.ID)
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// alternateLink returns the first Atom link with the "alternate" (or empty)
+// relation.
+func alternateLink(links []unmarshalFeedLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+
+	return ""
+}
+
+// createReleases creates a release.Releaseser slice from the unmarshalled
+// items alongside with the overall confidence.
+//
+// Each item is scored separately: the version found in the title scores 0.5
+// while the one found only in the links or enclosures scores 0.3; the
+// enclosure scores 0.5 while the link that only looks like a file scores 0.3.
+// The overall confidence is an average score of all items.
+// The releases of the provided commented items are marked as commented.
+func createReleases(items []unmarshalRelease, commented map[int]bool) (release.Releaseser, float64, appcaster.Errors) {
+	var releases []release.Releaser
+	var errors appcaster.Errors
+	var score float64
+
+	for i, item := range items {
+		var itemScore float64
+
+		// extract version
+		version := ""
+
+		versions, err := appcaster.ExtractSemanticVersions(item.title)
+		if err == nil {
+			version = versions[0]
+			itemScore += 0.5
+		} else {
+			candidates := item.links
+			for _, e := range item.enclosures {
+				candidates = append(candidates, e.URL)
+			}
+
+			for _, candidate := range candidates {
+				versions, err := appcaster.ExtractSemanticVersions(candidate)
+				if err == nil {
+					version = versions[0]
+					itemScore += 0.3
+					break
+				}
+			}
+		}
+
+		if version == "" {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", item.title, appcaster.ErrNoVersion))
+			continue
+		}
+
+		// new release (releases with malformed versions are kept with their
+		// version as a build, so they can still be sorted using the
+		// SparkleComparator)
+		r, err := release.NewLenient(version, "")
+		if err != nil {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", version, err))
+			r.SetBuild(version)
+		}
+
+		r.SetTitle(item.title)
+		r.SetDescription(item.description)
+
+		// publishedDateTime
+		p := release.NewPublishedDateTime()
+
+		err = p.Parse(item.published)
+		if err != nil {
+			errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.published, err))
+		}
+
+		r.SetPublishedDateTime(p)
+
+		// prerelease
+		if r.Version() != nil && r.Version().Prerelease() != "" {
+			r.SetIsPreRelease(true)
+		}
+
+		// downloads
+		if len(item.enclosures) > 0 {
+			for _, e := range item.enclosures {
+				d := release.NewDownload(e.URL, e.Type, e.Length)
+				r.AddDownload(*d)
+			}
+
+			itemScore += 0.5
+		} else {
+			for _, link := range item.links {
+				if regexFile.MatchString(link) {
+					d := release.NewDownload(link)
+					r.AddDownload(*d)
+					itemScore += 0.3
+					break
+				}
+			}
+		}
+
+		score += itemScore
+
+		// add release
+		r.SetIsCommented(commented[i])
+		releases = append(releases, r)
+	}
+
+	confidence := 0.0
+	if len(items) > 0 {
+		confidence = score / float64(len(items))
+	}
+
+	return release.NewReleases(releases), confidence, errors
+}
@@ -199,3 +199,144 @@ func TestAppcast_Unmarshal(t *testing.T) {
 	assert.Nil(t, p)
 	assert.Nil(t, a.Source())
 }
+
+func TestAppcast_UnmarshalReleaseErrors(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "invalid_pubdate.xml")
+
+	// test
+	_, errs := a.Unmarshal()
+	assert.Len(t, errs, 1)
+
+	re, ok := errs[0].(*appcaster.ReleaseError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, re.Index)
+	assert.Equal(t, "publishedDateTime", re.Field)
+	assert.Equal(t, "invalid", re.Value)
+	assert.Equal(t, 19, re.Line)
+	assert.Equal(t, 3, re.Column)
+	assert.EqualError(t, re.Err, "parsing of the published datetime failed")
+	assert.EqualError(t, re, "release #2 (parsing of the published datetime failed)")
+}
+
+func TestAppcast_UnmarshalMalformedVersion(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "invalid_version.xml")
+
+	// test
+	_, errs := a.Unmarshal()
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "release #2 (malformed version: invalid)")
+	assert.Equal(t, 4, a.Releases().Len())
+
+	r := a.Releases().Filtered()[1]
+	assert.Nil(t, r.Version())
+	assert.Equal(t, "invalid", r.Build())
+	assert.Equal(t, "1.1.0", r.Title())
+}
+
+func TestAppcast_UnmarshalLenient(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "malformed.xml")
+
+	// test
+	p, errs := a.Unmarshal()
+	assert.Nil(t, p)
+	assert.Len(t, errs, 1)
+
+	// test (lenient)
+	a = newTestAppcast("unmarshal", "malformed.xml")
+	a.SetLenient(true)
+
+	p, errs = a.Unmarshal()
+	assert.NotNil(t, p)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "line 13: unbalanced markup of the \"content\" element wrapped in CDATA")
+	assert.EqualError(t, errs[1], "line 17: unescaped \"&\" escaped")
+	assert.Equal(t, 4, a.Releases().Len())
+	assert.Equal(t, "<h3>Release 2.0.0</h3><p>Fixes &mdash; improvements<br></p>", a.Releases().First().Description())
+}
+
+func TestAppcast_Marshal(t *testing.T) {
+	testCases := map[string]string{
+		"default.xml":    "default.xml",
+		"prerelease.xml": "prerelease.xml",
+	}
+
+	// test (successful)
+	for unmarshalPath, marshalPath := range testCases {
+		// preparations
+		a := newTestAppcast("unmarshal", unmarshalPath)
+		a.SetOutput(new(appcaster.Output))
+
+		_, errors := a.Unmarshal()
+		assert.Nil(t, errors)
+
+		// test
+		content, err := a.Marshal()
+		assert.Nil(t, err)
+		assert.Equal(t, string(testdata("marshal", marshalPath)), string(content), marshalPath)
+		assert.Equal(t, content, a.Output().Content())
+		assert.NotNil(t, a.Output().Checksum())
+		assert.Equal(t, a, a.Output().Appcast())
+	}
+
+	// test (successful) [round trip]
+	a := newTestAppcast("unmarshal", "default.xml")
+	a.Unmarshal()
+
+	content, _ := a.Marshal()
+	a = newTestAppcast("marshal", "default.xml")
+	a.Source().SetContent(content)
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 4, a.Releases().Len())
+	assert.Equal(t, "2.0.0", a.Releases().First().Version().String())
+	assert.Equal(t, "2016-05-13T12:00:00+02:00", a.Releases().First().PublishedDateTime().String())
+
+	// test (error) [no releases]
+	a = new(Appcast)
+
+	content, err := a.Marshal()
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "no releases")
+}
+
+func TestAppcast_Uncomment(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "with_comments.xml")
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 3, a.Releases().Len())
+
+	// test (successful)
+	a = newTestAppcast("unmarshal", "with_comments.xml")
+
+	err := a.Uncomment()
+	assert.Nil(t, err)
+	assert.Contains(t, string(a.Source().Content()), "<!-- the unpublished releases are commented out -->")
+	assert.Equal(t, map[int]bool{0: true}, a.CommentedItems())
+
+	_, errors = a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 4, a.Releases().Len())
+
+	a.Releases().FilterByCommented()
+	assert.Len(t, a.Releases().Filtered(), 1)
+	assert.Equal(t, "2.0.0", a.Releases().First().Version().String())
+	assert.True(t, a.Releases().First().IsCommented())
+
+	a.Releases().ResetFilters()
+
+	a.Releases().FilterByCommented(true)
+	assert.Len(t, a.Releases().Filtered(), 3)
+
+	// test (error) [no source]
+	a = new(Appcast)
+
+	err = a.Uncomment()
+	assert.EqualError(t, err, "no source")
+	assert.Nil(t, a.Source())
+}
@@ -0,0 +1,128 @@
+package github
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// marshalFeed represents an Atom itself for the marshalling purposes.
+type marshalFeed struct {
+	XMLName xml.Name           `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string             `xml:"id"`
+	Title   string             `xml:"title"`
+	Updated string             `xml:"updated,omitempty"`
+	Entries []marshalFeedEntry `xml:"entry"`
+}
+
+// marshalFeedEntry represents an Atom entry for the marshalling purposes.
+type marshalFeedEntry struct {
+	ID      string              `xml:"id"`
+	Updated string              `xml:"updated,omitempty"`
+	Links   []marshalFeedLink   `xml:"link"`
+	Title   string              `xml:"title"`
+	Content *marshalFeedContent `xml:"content"`
+}
+
+// marshalFeedLink represents an Atom entry link for the marshalling purposes.
+type marshalFeedLink struct {
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Length int    `xml:"length,attr,omitempty"`
+}
+
+// marshalFeedContent represents an Atom entry content for the marshalling
+// purposes.
+type marshalFeedContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// marshal marshals the Appcast.releases from the provided Appcast pointer into
+// the GitHub releases Atom feed content.
+//
+// The release version becomes the entry tag, the release notes link becomes
+// the "alternate" link and each download becomes the "enclosure" link.
+func marshal(a *Appcast) ([]byte, error) {
+	if a.Releases() == nil {
+		return nil, fmt.Errorf("no releases")
+	}
+
+	feed := marshalFeed{
+		ID:    "tag:github.com,2008:releases",
+		Title: "Releases",
+	}
+
+	var updated *time.Time
+
+	for _, r := range a.Releases().Filtered() {
+		tag := r.Build()
+		if r.Version() != nil {
+			tag = r.Version().Original()
+		}
+
+		entry := marshalFeedEntry{
+			ID:    "tag:github.com,2008:Repository/0/" + tag,
+			Title: r.Title(),
+		}
+
+		if r.Description() != "" {
+			entry.Content = &marshalFeedContent{Type: "html", Value: r.Description()}
+		}
+
+		if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+			entry.Updated = p.Time().Format(time.RFC3339)
+
+			if updated == nil || p.Time().After(*updated) {
+				updated = p.Time()
+			}
+		}
+
+		if r.ReleaseNotesLink() != "" {
+			entry.Links = append(entry.Links, marshalFeedLink{
+				Rel:  "alternate",
+				Type: "text/html",
+				Href: r.ReleaseNotesLink(),
+			})
+		}
+
+		for _, d := range r.Downloads() {
+			entry.Links = append(entry.Links, marshalFeedLink{
+				Rel:    "enclosure",
+				Type:   d.Filetype(),
+				Href:   d.Url(),
+				Length: d.Length(),
+			})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	if updated != nil {
+		feed.Updated = updated.Format(time.RFC3339)
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.Write(content)
+	buf.WriteString("\n")
+
+	content = buf.Bytes()
+
+	if a.Output() != nil {
+		a.Output().SetContent(content)
+		a.Output().GenerateChecksum(appcaster.SHA256)
+		a.Output().SetAppcast(a)
+	}
+
+	return content, nil
+}
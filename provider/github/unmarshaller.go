@@ -1,7 +1,6 @@
 package github
 
 import (
-	"encoding/xml"
 	"fmt"
 	"regexp"
 
@@ -36,67 +35,87 @@ func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
 		a.Source().SetAppcast(a)
 	}
 
-	err := xml.Unmarshal(a.Source().Content(), &feed)
+	content, repairs, err := a.UnmarshalContent(a.Source().Content(), &feed)
 	if err != nil {
-		return nil, append(errors, err)
+		return nil, append(errors, append(repairs, err)...)
 	}
 
-	r, errors := createReleases(feed)
+	r, errs := createReleases(feed, a.CommentedItems())
+	errs.Locate(appcaster.LocateElements(content, "entry"))
+	errs = append(repairs, errs...)
 
 	a.SetReleases(r)
 
-	return a, errors
+	return a, errs
 }
 
 // createReleases creates a release.Releaseser slice from the unmarshalled feed.
-func createReleases(feed unmarshalFeed) (release.Releaseser, []error) {
+// The releases of the provided commented items are marked as commented.
+func createReleases(feed unmarshalFeed, commented map[int]bool) (release.Releaseser, appcaster.Errors) {
 	var items []release.Releaser
-	var errors []error
+	var errors appcaster.Errors
 
 	for i, entry := range feed.Entries {
-		version := ""
-
-		re := regexp.MustCompile(`\/.*\/(.*$)`)
-		if re.MatchString(entry.ID) {
-			// extract last part that represents version
-			versionMatches := re.FindAllStringSubmatch(entry.ID, 1)
-			version = versionMatches[0][1]
+		r, errs := createRelease(i, entry)
+		errors = append(errors, errs...)
 
-			// remove the first "v"
-			re := regexp.MustCompile(`^v`)
-			version = re.ReplaceAllString(version, "")
+		// add release
+		if r != nil {
+			r.SetIsCommented(commented[i])
+			items = append(items, r)
 		}
+	}
 
-		// new release
-		r, err := release.New(version, "")
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (%s)", i+1, err.Error()))
-			continue
-		}
+	return release.NewReleases(items), errors
+}
 
-		r.SetTitle(entry.Title)
-		r.SetDescription(entry.Content)
+// createRelease creates a single release from the provided unmarshalled entry
+// and its 0-based index. Returns nil, if the release can't be created.
+func createRelease(i int, entry unmarshalFeedEntry) (*release.Release, appcaster.Errors) {
+	var errors appcaster.Errors
 
-		// publishedDateTime
-		p := release.NewPublishedDateTime()
+	version := ""
 
-		err = p.Parse(entry.Updated)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (%s)", i+1, err.Error()))
-		}
+	re := regexp.MustCompile(`\/.*\/(.*$)`)
+	if re.MatchString(entry.ID) {
+		// extract last part that represents version
+		versionMatches := re.FindAllStringSubmatch(entry.ID, 1)
+		version = versionMatches[0][1]
 
-		r.SetPublishedDateTime(p)
+		// remove the first "v"
+		re := regexp.MustCompile(`^v`)
+		version = re.ReplaceAllString(version, "")
+	}
 
-		// prerelease
-		if r.Version().Prerelease() != "" {
-			r.SetIsPreRelease(true)
+	// new release (releases with malformed versions are kept with their tag as
+	// a build, so they can still be sorted using the SparkleComparator)
+	r, err := release.NewLenient(version, "")
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "version", version, err))
+		if version == "" {
+			return nil, errors
 		}
 
-		// add release
-		if r != nil {
-			items = append(items, r)
-		}
+		r.SetBuild(version)
 	}
 
-	return release.NewReleases(items), errors
+	r.SetTitle(entry.Title)
+	r.SetDescription(entry.Content)
+
+	// publishedDateTime
+	p := release.NewPublishedDateTime()
+
+	err = p.Parse(entry.Updated)
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", entry.Updated, err))
+	}
+
+	r.SetPublishedDateTime(p)
+
+	// prerelease
+	if r.Version() != nil && r.Version().Prerelease() != "" {
+		r.SetIsPreRelease(true)
+	}
+
+	return r, errors
 }
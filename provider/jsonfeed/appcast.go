@@ -0,0 +1,69 @@
+// Package jsonfeed adds support for the JSON Feed (https://jsonfeed.org/)
+// releases feed.
+package jsonfeed
+
+import (
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Appcaster is the interface that wraps the Appcast methods.
+type Appcaster interface {
+	appcaster.Appcaster
+	Marshal() ([]byte, error)
+	Feed() *Feed
+	SetFeed(feed *Feed)
+}
+
+// Appcast represents the appcast itself.
+type Appcast struct {
+	appcaster.Appcast
+	feed *Feed
+}
+
+// Feed represents the appcast feed top-level information.
+type Feed struct {
+	Title       string
+	HomePageUrl string
+	FeedUrl     string
+	Description string
+	Language    string
+}
+
+// New returns a new Appcast instance pointer. The source can be passed as a
+// parameter.
+func New(src ...interface{}) *Appcast {
+	a := new(Appcast)
+
+	if len(src) > 0 {
+		src := src[0].(appcaster.Sourcer)
+		a.SetSource(src)
+	}
+
+	return a
+}
+
+// Unmarshal unmarshals the Appcast.source.content into the Appcast.releases and
+// Appcast.feed.
+//
+// It returns both: the supported provider-specific appcast implementing the
+// Appcaster interface and an errors slice.
+func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
+	return unmarshal(a)
+}
+
+// Marshal marshals the Appcast.releases and Appcast.feed into the JSON Feed
+// version 1.1 content. If the Appcast.output is set, the content is also set as
+// its content alongside with the SHA256 checksum.
+func (a *Appcast) Marshal() ([]byte, error) {
+	return marshal(a)
+}
+
+// Feed is an Appcast.feed getter.
+func (a *Appcast) Feed() *Feed {
+	return a.feed
+}
+
+// SetFeed is an Appcast.feed setter.
+func (a *Appcast) SetFeed(feed *Feed) {
+	a.feed = feed
+}
@@ -0,0 +1,322 @@
+package jsonfeed
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// workingDir returns a current working directory path. If it's not available
+// prints an error to os.Stdout and exits with error status 1.
+func workingDir() string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	return pwd
+}
+
+// testdata returns a file content as a byte slice from the provided testdata
+// paths. If the file is not found, prints an error to os.Stdout and exits with
+// exit status 1.
+func testdata(paths ...string) []byte {
+	path := testdataPath(paths...)
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Println(fmt.Errorf(err.Error()))
+		os.Exit(1)
+	}
+
+	return content
+}
+
+// testdataPath returns a full path for the provided testdata paths.
+func testdataPath(paths ...string) string {
+	return filepath.Join(workingDir(), "./testdata/", filepath.Join(paths...))
+}
+
+// newTestAppcast creates a new Appcast instance for testing purposes and
+// returns its pointer. By default the source is LocalSource and points to the
+// "JSON Feed" default.json testdata.
+func newTestAppcast(paths ...string) *Appcast {
+	var content []byte
+
+	if len(paths) > 0 {
+		content = testdata(paths...)
+	} else {
+		content = testdata("unmarshal", "default.json")
+	}
+
+	s := new(appcaster.Source)
+	s.SetContent(content)
+	s.GenerateChecksum(appcaster.SHA256)
+	s.SetProvider(appcaster.Provider(0))
+
+	a := new(Appcast)
+	a.SetSource(s)
+
+	return a
+}
+
+func TestNew(t *testing.T) {
+	// test (without source)
+	a := New()
+	assert.IsType(t, Appcast{}, *a)
+	assert.Nil(t, a.Source())
+
+	// test (with source)
+	src := new(appcaster.Source)
+	src.SetContent([]byte("content"))
+	src.SetProvider(appcaster.Provider(0))
+
+	a = New(src)
+	assert.IsType(t, Appcast{}, *a)
+	assert.NotNil(t, a.Source())
+}
+
+func TestAppcast_Unmarshal(t *testing.T) {
+	type testCase struct {
+		path     string
+		appcast  appcaster.Appcaster
+		releases map[string][]string
+		errors   []string
+	}
+
+	testCases := []testCase{
+		{
+			path:    "default.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T12:00:00+02:00", "200", "10.10"},
+				"1.1.0": {"2016-05-12T12:00:00+02:00", "110", "10.9"},
+				"1.0.1": {"2016-05-11T12:00:00+02:00", "101", "10.9"},
+				"1.0.0": {"2016-05-10T12:00:00+02:00", "100", "10.9"},
+			},
+		},
+		{
+			path:    "empty.json",
+			appcast: &Appcast{},
+		},
+		{
+			path:    "invalid_pubdate.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T12:00:00+02:00", "200", "10.10"},
+				"1.1.0": {"", "110", "10.9"},
+				"1.0.1": {"2016-05-11T12:00:00+02:00", "101", "10.9"},
+				"1.0.0": {"2016-05-10T12:00:00+02:00", "100", "10.9"},
+			},
+			errors: []string{
+				"release #2 (parsing of the published datetime failed)",
+			},
+		},
+		{
+			path: "invalid_syntax.json",
+			errors: []string{
+				"unexpected end of JSON input",
+			},
+		},
+		{
+			path:    "invalid_title_version.json",
+			appcast: &Appcast{},
+			errors: []string{
+				"release #2 (malformed version: 99999999999999999999.1.0)",
+			},
+		},
+		{
+			path:    "invalid_version.json",
+			appcast: &Appcast{},
+			errors: []string{
+				"release #2 (malformed version: invalid)",
+			},
+		},
+		{
+			path:    "prerelease.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0-beta": {"2016-05-13T12:00:00+02:00", "200", "10.10"},
+				"1.1.0":      {"2016-05-12T12:00:00+02:00", "110", "10.9"},
+				"1.0.1":      {"2016-05-11T12:00:00+02:00", "101", "10.9"},
+				"1.0.0":      {"2016-05-10T12:00:00+02:00", "100", "10.9"},
+			},
+		},
+		{
+			path:    "without_extension.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T12:00:00+02:00", "", ""},
+				"1.1.0": {"2016-05-12T12:00:00+02:00", "", ""},
+				"1.0.1": {"2016-05-11T12:00:00+02:00", "", ""},
+				"1.0.0": {"2016-05-10T12:00:00+02:00", "", ""},
+			},
+		},
+	}
+
+	// test
+	for _, testCase := range testCases {
+		// preparations
+		a := newTestAppcast("unmarshal", testCase.path)
+
+		// test
+		assert.IsType(t, &Appcast{}, a)
+		assert.Nil(t, a.Source().Appcast())
+		assert.Nil(t, a.feed)
+		assert.Empty(t, a.Releases())
+
+		appcast, errors := a.Unmarshal()
+
+		if testCase.appcast != nil {
+			assert.IsType(t, testCase.appcast, appcast, fmt.Sprintf("%s: appcast type mismatch", testCase.path))
+			assert.IsType(t, testCase.appcast, a.Source().Appcast())
+		} else {
+			assert.Equal(t, testCase.appcast, appcast, fmt.Sprintf("%s: appcast type mismatch", testCase.path))
+		}
+
+		if len(testCase.errors) == 0 {
+			// successful
+			assert.Nil(t, errors, fmt.Sprintf("%s: errors not nil", testCase.path))
+
+			assert.IsType(t, &Feed{}, a.feed)
+			assert.Equal(t, "App", a.feed.Title)
+			assert.Equal(t, "https://example.com/app/", a.feed.HomePageUrl)
+			assert.Equal(t, "https://example.com/app/feed.json", a.feed.FeedUrl)
+			assert.Equal(t, "App Description", a.feed.Description)
+			assert.Equal(t, "en", a.feed.Language)
+
+			releases := testCase.releases
+			assert.Len(t, releases, a.Releases().Len())
+
+			for _, r := range a.Releases().Filtered() {
+				v := r.Version().String()
+				assert.Equal(t, fmt.Sprintf("Release %s", v), r.Title())
+				assert.Equal(t, fmt.Sprintf("<p>Release %s Description</p>", v), r.Description())
+				assert.Equal(t, fmt.Sprintf("https://example.com/changelogs/%s.html", v), r.ReleaseNotesLink())
+				assert.Equal(t, releases[v][0], r.PublishedDateTime().String())
+				assert.Equal(t, releases[v][1], r.Build())
+				assert.Equal(t, releases[v][2], r.MinimumSystemVersion())
+				assert.Equal(t, r.Version().Prerelease() != "", r.IsPreRelease())
+
+				// downloads
+				assert.Equal(t, fmt.Sprintf("https://example.com/app_%s.dmg", v), r.Downloads()[0].Url())
+				assert.Equal(t, "application/octet-stream", r.Downloads()[0].Filetype())
+				assert.Equal(t, 100000, r.Downloads()[0].Length())
+			}
+		} else {
+			// error (unmarshalling failure)
+			assert.Len(t, errors, len(testCase.errors), fmt.Sprintf("%s: errors length mismatch", testCase.path))
+
+			for i, errorMsg := range testCase.errors {
+				err := errors[i]
+				assert.EqualError(t, err, errorMsg)
+			}
+		}
+	}
+
+	// test (successful) [item id]
+	releases, errs := createReleases(unmarshalFeed{
+		Items: []unmarshalFeedItem{
+			{ID: "1234", Title: "Release 2.0.0", DatePublished: "2016-05-13T12:00:00+02:00"},
+			{ID: "v1.0.0", Title: "Release", DatePublished: "2016-05-10T12:00:00+02:00"},
+			{ID: "1234", Title: "Release", DatePublished: "2016-05-10T12:00:00+02:00"},
+		},
+	})
+
+	assert.Equal(t, 2, releases.Len())
+	assert.Equal(t, "2.0.0", releases.Filtered()[0].Version().String())
+	assert.Equal(t, "1.0.0", releases.Filtered()[1].Version().String())
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "release #3 (no version)")
+
+	// test (error) [no source]
+	a := new(Appcast)
+
+	p, errors := a.Unmarshal()
+
+	assert.Len(t, errors, 1)
+	err := errors[0]
+
+	assert.Error(t, err)
+	assert.EqualError(t, err, "no source")
+	assert.Nil(t, p)
+	assert.Nil(t, a.Source())
+	assert.Nil(t, a.feed)
+}
+
+func TestAppcast_Marshal(t *testing.T) {
+	testCases := map[string]string{
+		"default.json":    "default.json",
+		"empty.json":      "empty.json",
+		"prerelease.json": "prerelease.json",
+	}
+
+	// test (successful)
+	for unmarshalPath, marshalPath := range testCases {
+		// preparations
+		a := newTestAppcast("unmarshal", unmarshalPath)
+		a.SetOutput(new(appcaster.Output))
+
+		_, errors := a.Unmarshal()
+		assert.Nil(t, errors)
+
+		// test
+		content, err := a.Marshal()
+		assert.Nil(t, err)
+		assert.Equal(t, string(testdata("marshal", marshalPath)), string(content), marshalPath)
+		assert.Equal(t, content, a.Output().Content())
+		assert.NotNil(t, a.Output().Checksum())
+		assert.Equal(t, a, a.Output().Appcast())
+	}
+
+	// test (successful) [round trip]
+	a := newTestAppcast("unmarshal", "default.json")
+	a.Unmarshal()
+
+	content, _ := a.Marshal()
+	a = newTestAppcast("marshal", "default.json")
+	a.Source().SetContent(content)
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 4, a.Releases().Len())
+	assert.Equal(t, "2.0.0", a.Releases().First().Version().String())
+	assert.Equal(t, "200", a.Releases().First().Build())
+
+	// test (successful) [original version]
+	a = newTestAppcast("unmarshal", "default.json")
+	a.Unmarshal()
+	a.Releases().First().SetVersionString("v2.0")
+
+	content, _ = a.Marshal()
+	assert.Contains(t, string(content), `"version": "v2.0"`)
+	assert.NotContains(t, string(content), `"version": "2.0.0"`)
+
+	// test (error) [no releases]
+	a = new(Appcast)
+
+	content, err := a.Marshal()
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "no releases")
+}
+
+func TestAppcast_Feed(t *testing.T) {
+	a := newTestAppcast()
+	assert.Equal(t, a.feed, a.Feed())
+}
+
+func TestAppcast_SetFeed(t *testing.T) {
+	// preparations
+	a := newTestAppcast()
+	assert.Nil(t, a.feed)
+
+	// test
+	a.SetFeed(&Feed{})
+	assert.NotNil(t, a.feed)
+}
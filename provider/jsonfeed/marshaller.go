@@ -0,0 +1,134 @@
+package jsonfeed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Version represents the JSON Feed version used for marshalling.
+const Version = "https://jsonfeed.org/version/1.1"
+
+// marshalFeed represents a JSON Feed itself for the marshalling purposes.
+type marshalFeed struct {
+	Version     string            `json:"version"`
+	Title       string            `json:"title"`
+	HomePageUrl string            `json:"home_page_url,omitempty"`
+	FeedUrl     string            `json:"feed_url,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Language    string            `json:"language,omitempty"`
+	Items       []marshalFeedItem `json:"items"`
+}
+
+// marshalFeedItem represents a single JSON Feed item for the marshalling
+// purposes.
+type marshalFeedItem struct {
+	ID            string                  `json:"id"`
+	Url           string                  `json:"url,omitempty"`
+	Title         string                  `json:"title,omitempty"`
+	ContentHtml   string                  `json:"content_html"`
+	DatePublished string                  `json:"date_published,omitempty"`
+	Attachments   []marshalFeedAttachment `json:"attachments,omitempty"`
+	Appcast       *marshalFeedExtension   `json:"_appcast,omitempty"`
+}
+
+// marshalFeedAttachment represents a single JSON Feed item attachment for the
+// marshalling purposes.
+type marshalFeedAttachment struct {
+	Url         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	SizeInBytes int    `json:"size_in_bytes,omitempty"`
+}
+
+// marshalFeedExtension represents a JSON Feed item "_appcast" custom extension
+// for the marshalling purposes.
+type marshalFeedExtension struct {
+	Version              string `json:"version,omitempty"`
+	Build                string `json:"build,omitempty"`
+	MinimumSystemVersion string `json:"minimum_system_version,omitempty"`
+	MaximumSystemVersion string `json:"maximum_system_version,omitempty"`
+	IsPreRelease         bool   `json:"prerelease,omitempty"`
+}
+
+// marshal marshals the Appcast.releases and Appcast.feed from the provided
+// Appcast pointer into the JSON Feed content.
+func marshal(a *Appcast) ([]byte, error) {
+	if a.Releases() == nil {
+		return nil, fmt.Errorf("no releases")
+	}
+
+	feed := marshalFeed{
+		Version: Version,
+		Items:   []marshalFeedItem{},
+	}
+
+	if a.feed != nil {
+		feed.Title = a.feed.Title
+		feed.HomePageUrl = a.feed.HomePageUrl
+		feed.FeedUrl = a.feed.FeedUrl
+		feed.Description = a.feed.Description
+		feed.Language = a.feed.Language
+	}
+
+	for _, r := range a.Releases().Filtered() {
+		item := marshalFeedItem{
+			ID:          r.VersionOrBuildString(),
+			Url:         r.ReleaseNotesLink(),
+			Title:       r.Title(),
+			ContentHtml: r.Description(),
+			Appcast: &marshalFeedExtension{
+				Build:                r.Build(),
+				MinimumSystemVersion: r.MinimumSystemVersion(),
+				MaximumSystemVersion: r.MaximumSystemVersion(),
+				IsPreRelease:         r.IsPreRelease(),
+			},
+		}
+
+		if r.Version() != nil {
+			item.Appcast.Version = r.Version().Original()
+		}
+
+		if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+			item.DatePublished = p.Time().Format(time.RFC3339)
+		}
+
+		for _, d := range r.Downloads() {
+			mimeType := d.Filetype()
+			if mimeType == "" {
+				mimeType = "application/octet-stream"
+			}
+
+			item.Attachments = append(item.Attachments, marshalFeedAttachment{
+				Url:         d.Url(),
+				MimeType:    mimeType,
+				SizeInBytes: d.Length(),
+			})
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(feed)
+	if err != nil {
+		return nil, err
+	}
+
+	content := buf.Bytes()
+
+	if a.Output() != nil {
+		a.Output().SetContent(content)
+		a.Output().GenerateChecksum(appcaster.SHA256)
+		a.Output().SetAppcast(a)
+	}
+
+	return content, nil
+}
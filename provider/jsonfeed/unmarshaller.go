@@ -0,0 +1,190 @@
+package jsonfeed
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// reVersion matches the whole item "id" that looks like a version.
+var reVersion = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)+([-+][0-9A-Za-z.+-]+)?$`)
+
+// unmarshalFeed represents a JSON Feed itself for the unmarshalling purposes.
+type unmarshalFeed struct {
+	Version     string              `json:"version"`
+	Title       string              `json:"title"`
+	HomePageUrl string              `json:"home_page_url"`
+	FeedUrl     string              `json:"feed_url"`
+	Description string              `json:"description"`
+	Language    string              `json:"language"`
+	Items       []unmarshalFeedItem `json:"items"`
+}
+
+// unmarshalFeedItem represents a single JSON Feed item for the unmarshalling
+// purposes.
+type unmarshalFeedItem struct {
+	ID            string                    `json:"id"`
+	Url           string                    `json:"url"`
+	Title         string                    `json:"title"`
+	ContentHtml   string                    `json:"content_html"`
+	ContentText   string                    `json:"content_text"`
+	DatePublished string                    `json:"date_published"`
+	Attachments   []unmarshalFeedAttachment `json:"attachments"`
+	Appcast       *unmarshalFeedExtension   `json:"_appcast"`
+}
+
+// unmarshalFeedAttachment represents a single JSON Feed item attachment for the
+// unmarshalling purposes.
+type unmarshalFeedAttachment struct {
+	Url         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	Title       string `json:"title"`
+	SizeInBytes int    `json:"size_in_bytes"`
+}
+
+// unmarshalFeedExtension represents a JSON Feed item "_appcast" custom
+// extension holding the release data that JSON Feed doesn't provide for the
+// unmarshalling purposes.
+type unmarshalFeedExtension struct {
+	Version              string `json:"version"`
+	Build                string `json:"build"`
+	MinimumSystemVersion string `json:"minimum_system_version"`
+	MaximumSystemVersion string `json:"maximum_system_version"`
+	IsPreRelease         bool   `json:"prerelease"`
+}
+
+// unmarshal unmarshals the Appcast.source.content from the provided Appcast
+// pointer into its Appcast.releases and Appcast.feed fields.
+func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
+	var feed unmarshalFeed
+	var errors []error
+
+	if a.Source() == nil || len(a.Source().Content()) == 0 {
+		return nil, append(errors, fmt.Errorf("no source"))
+	}
+
+	if a.Source().Appcast() == nil {
+		a.Source().SetAppcast(a)
+	}
+
+	err := json.Unmarshal(a.Source().Content(), &feed)
+	if err != nil {
+		return nil, append(errors, err)
+	}
+
+	r, errors := createReleases(feed)
+
+	a.SetReleases(r)
+
+	a.feed = &Feed{
+		Title:       feed.Title,
+		HomePageUrl: feed.HomePageUrl,
+		FeedUrl:     feed.FeedUrl,
+		Description: feed.Description,
+		Language:    feed.Language,
+	}
+
+	return a, errors
+}
+
+// createReleases creates a release.Releaseser slice from the unmarshalled feed.
+//
+// The release version is taken from the "_appcast" extension, if available.
+// Otherwise, the first semantic version found in the item "title" is used and,
+// as a last resort, the item "id" when it looks like a version, since it's
+// often a URL or a plain number. The title version is also used when the
+// extension version is malformed.
+func createReleases(feed unmarshalFeed) (release.Releaseser, appcaster.Errors) {
+	var items []release.Releaser
+	var errors appcaster.Errors
+
+	for i, item := range feed.Items {
+		var version, build string
+
+		if item.Appcast != nil {
+			version = item.Appcast.Version
+			build = item.Appcast.Build
+		}
+
+		var titleVersion string
+		if versions, err := appcaster.ExtractSemanticVersions(item.Title); err == nil {
+			titleVersion = versions[0]
+		}
+
+		if version == "" {
+			version = titleVersion
+		}
+
+		if version == "" && reVersion.MatchString(item.ID) {
+			version = item.ID
+		}
+
+		if version == "" && build == "" {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", "", appcaster.ErrNoVersion))
+			continue
+		} else if version == "" && build != "" {
+			version = build
+		}
+
+		// new release (releases with malformed versions are kept with their
+		// version as a build, so they can still be sorted using the
+		// SparkleComparator)
+		r, err := release.NewLenient(version, build)
+		if err != nil && titleVersion != "" && titleVersion != version {
+			version = titleVersion
+			r, err = release.NewLenient(version, build)
+		}
+
+		if err != nil {
+			errors = append(errors, appcaster.NewReleaseError(i, "version", version, err))
+			if build == "" {
+				r.SetBuild(version)
+			}
+		}
+
+		r.SetTitle(item.Title)
+		r.SetReleaseNotesLink(item.Url)
+
+		if item.ContentHtml != "" {
+			r.SetDescription(item.ContentHtml)
+		} else {
+			r.SetDescription(item.ContentText)
+		}
+
+		// publishedDateTime
+		p := release.NewPublishedDateTime()
+
+		err = p.Parse(item.DatePublished)
+		if err != nil {
+			errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.DatePublished, err))
+		}
+
+		r.SetPublishedDateTime(p)
+
+		// extension
+		if item.Appcast != nil {
+			r.SetMinimumSystemVersion(item.Appcast.MinimumSystemVersion)
+			r.SetMaximumSystemVersion(item.Appcast.MaximumSystemVersion)
+			r.SetIsPreRelease(item.Appcast.IsPreRelease)
+		}
+
+		// prerelease
+		if r.Version() != nil && r.Version().Prerelease() != "" {
+			r.SetIsPreRelease(true)
+		}
+
+		// downloads
+		for _, attachment := range item.Attachments {
+			d := release.NewDownload(attachment.Url, attachment.MimeType, attachment.SizeInBytes)
+			r.AddDownload(*d)
+		}
+
+		// add release
+		items = append(items, r)
+	}
+
+	return release.NewReleases(items), errors
+}
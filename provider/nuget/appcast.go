@@ -0,0 +1,48 @@
+// Package nuget adds support for the NuGet packages feeds: both the NuGet v2
+// OData Atom feed (as served by the Chocolatey repositories) and the NuGet v3
+// registration JSON.
+package nuget
+
+import "github.com/victorpopkov/go-appcast/appcaster"
+
+// Appcaster is the interface that wraps the Appcast methods.
+type Appcaster interface {
+	appcaster.Appcaster
+	Uncomment() error
+}
+
+// Appcast represents the appcast itself.
+type Appcast struct {
+	appcaster.Appcast
+}
+
+// New returns a new Appcast instance pointer. The source can be passed as a
+// parameter.
+func New(src ...interface{}) *Appcast {
+	a := new(Appcast)
+
+	if len(src) > 0 {
+		src := src[0].(appcaster.Sourcer)
+		a.SetSource(src)
+	}
+
+	return a
+}
+
+// Unmarshal unmarshals the Appcast.source.content into the Appcast.releases.
+// Both the NuGet v2 OData Atom feed and the NuGet v3 registration JSON are
+// supported. As both list package versions in the ascending order, the
+// releases are sorted by versions in the descending order to match other
+// providers.
+//
+// It returns both: the supported provider-specific appcast implementing the
+// Appcaster interface and an errors slice.
+func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
+	return unmarshal(a)
+}
+
+// Uncomment uncomments the commented out entries in Appcast.source.content. The
+// releases of these entries are marked as commented on unmarshalling.
+func (a *Appcast) Uncomment() error {
+	return a.UncommentItems("entry")
+}
@@ -0,0 +1,263 @@
+package nuget
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// workingDir returns a current working directory path. If it's not available
+// prints an error to os.Stdout and exits with error status 1.
+func workingDir() string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	return pwd
+}
+
+// testdata returns a file content as a byte slice from the provided testdata
+// paths. If the file is not found, prints an error to os.Stdout and exits with
+// exit status 1.
+func testdata(paths ...string) []byte {
+	path := testdataPath(paths...)
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Println(fmt.Errorf(err.Error()))
+		os.Exit(1)
+	}
+
+	return content
+}
+
+// testdataPath returns a full path for the provided testdata paths.
+func testdataPath(paths ...string) string {
+	return filepath.Join(workingDir(), "./testdata/", filepath.Join(paths...))
+}
+
+// newTestAppcast creates a new Appcast instance for testing purposes and
+// returns its pointer. By default the source is LocalSource and points to the
+// "NuGet Feed" default.xml testdata.
+func newTestAppcast(paths ...string) *Appcast {
+	var content []byte
+
+	if len(paths) > 0 {
+		content = testdata(paths...)
+	} else {
+		content = testdata("unmarshal", "default.xml")
+	}
+
+	s := new(appcaster.Source)
+	s.SetContent(content)
+	s.GenerateChecksum(appcaster.SHA256)
+	s.SetProvider(appcaster.Provider(0))
+
+	a := new(Appcast)
+	a.SetSource(s)
+
+	return a
+}
+
+func TestNew(t *testing.T) {
+	// test (without source)
+	a := New()
+	assert.IsType(t, Appcast{}, *a)
+	assert.Nil(t, a.Source())
+
+	// test (with source)
+	src := new(appcaster.Source)
+	src.SetContent([]byte("content"))
+	src.SetProvider(appcaster.Provider(0))
+
+	a = New(src)
+	assert.IsType(t, Appcast{}, *a)
+	assert.NotNil(t, a.Source())
+}
+
+func TestAppcast_Unmarshal(t *testing.T) {
+	type testCase struct {
+		path     string
+		appcast  appcaster.Appcaster
+		releases map[string][]string
+		errors   []string
+	}
+
+	testCases := []testCase{
+		{
+			path:    "default.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T10:00:00Z", "https://example.com/v3-flatcontainer/app/2.0.0/app.2.0.0.nupkg", "0"},
+				"1.1.0": {"2016-05-12T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.1.0/app.1.1.0.nupkg", "0"},
+				"1.0.1": {"2016-05-11T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.0.1/app.1.0.1.nupkg", "0"},
+				"1.0.0": {"2016-05-10T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.0.0/app.1.0.0.nupkg", "0"},
+			},
+		},
+		{
+			path:    "default.xml",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T10:00:00", "https://example.com/api/v2/package/app/2.0.0", "100000"},
+				"1.1.0": {"2016-05-12T10:00:00", "https://example.com/api/v2/package/app/1.1.0", "100000"},
+				"1.0.1": {"2016-05-11T10:00:00", "https://example.com/api/v2/package/app/1.0.1", "100000"},
+				"1.0.0": {"2016-05-10T10:00:00", "https://example.com/api/v2/package/app/1.0.0", "100000"},
+			},
+		},
+		{
+			path:    "empty.xml",
+			appcast: &Appcast{},
+		},
+		{
+			path:    "invalid_pubdate.xml",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0": {"2016-05-13T10:00:00", "https://example.com/api/v2/package/app/2.0.0", "100000"},
+				"1.1.0": {"", "https://example.com/api/v2/package/app/1.1.0", "100000"},
+				"1.0.1": {"2016-05-11T10:00:00", "https://example.com/api/v2/package/app/1.0.1", "100000"},
+				"1.0.0": {"2016-05-10T10:00:00", "https://example.com/api/v2/package/app/1.0.0", "100000"},
+			},
+			errors: []string{
+				"release #3 (parsing of the published datetime failed)",
+			},
+		},
+		{
+			path: "invalid_syntax.json",
+			errors: []string{
+				"unexpected end of JSON input",
+			},
+		},
+		{
+			path: "invalid_tag.xml",
+			errors: []string{
+				"XML syntax error on line 29: element <PackageSize> closed by </properties>",
+			},
+		},
+		{
+			path:    "invalid_version.json",
+			appcast: &Appcast{},
+			errors: []string{
+				"release #3 (malformed version: invalid)",
+			},
+		},
+		{
+			path:    "invalid_version.xml",
+			appcast: &Appcast{},
+			errors: []string{
+				"release #3 (malformed version: invalid)",
+			},
+		},
+		{
+			path:    "not_inlined.json",
+			appcast: &Appcast{},
+			errors: []string{
+				"page #2 (no inlined releases)",
+			},
+		},
+		{
+			path:    "prerelease.json",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0-beta": {"2016-05-13T10:00:00Z", "https://example.com/v3-flatcontainer/app/2.0.0-beta/app.2.0.0-beta.nupkg", "0"},
+				"1.1.0":      {"2016-05-12T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.1.0/app.1.1.0.nupkg", "0"},
+				"1.0.1":      {"2016-05-11T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.0.1/app.1.0.1.nupkg", "0"},
+				"1.0.0":      {"2016-05-10T10:00:00Z", "https://example.com/v3-flatcontainer/app/1.0.0/app.1.0.0.nupkg", "0"},
+			},
+		},
+		{
+			path:    "prerelease.xml",
+			appcast: &Appcast{},
+			releases: map[string][]string{
+				"2.0.0-beta": {"2016-05-13T10:00:00", "https://example.com/api/v2/package/app/2.0.0-beta", "100000"},
+				"1.1.0":      {"2016-05-12T10:00:00", "https://example.com/api/v2/package/app/1.1.0", "100000"},
+				"1.0.1":      {"2016-05-11T10:00:00", "https://example.com/api/v2/package/app/1.0.1", "100000"},
+				"1.0.0":      {"2016-05-10T10:00:00", "https://example.com/api/v2/package/app/1.0.0", "100000"},
+			},
+		},
+	}
+
+	// test
+	for _, testCase := range testCases {
+		// preparations
+		a := newTestAppcast("unmarshal", testCase.path)
+
+		// test
+		assert.IsType(t, &Appcast{}, a)
+		assert.Nil(t, a.Source().Appcast())
+		assert.Empty(t, a.Releases())
+
+		appcast, errors := a.Unmarshal()
+
+		if testCase.appcast != nil {
+			assert.IsType(t, testCase.appcast, appcast, fmt.Sprintf("%s: appcast type mismatch", testCase.path))
+			assert.IsType(t, testCase.appcast, a.Source().Appcast())
+		} else {
+			assert.Equal(t, testCase.appcast, appcast, fmt.Sprintf("%s: appcast type mismatch", testCase.path))
+		}
+
+		if len(testCase.errors) == 0 {
+			// successful
+			assert.Nil(t, errors, fmt.Sprintf("%s: errors not nil", testCase.path))
+
+			releases := testCase.releases
+			assert.Len(t, releases, a.Releases().Len())
+
+			if len(releases) > 0 {
+				assert.Equal(t, 4, a.Releases().Len())
+				assert.Contains(t, a.Releases().First().Version().String(), "2.0.0")
+			}
+
+			for _, r := range a.Releases().Filtered() {
+				v := r.Version().String()
+				assert.Equal(t, fmt.Sprintf("Release %s", v), r.Title())
+				assert.Equal(t, fmt.Sprintf("Release %s Description", v), r.Description())
+				assert.Equal(t, fmt.Sprintf("https://example.com/changelogs/%s.html", v), r.ReleaseNotesLink())
+				assert.Equal(t, releases[v][0], r.PublishedDateTime().String())
+				assert.Equal(t, r.Version().Prerelease() != "", r.IsPreRelease())
+
+				// downloads
+				assert.Equal(t, releases[v][1], r.Downloads()[0].Url())
+				assert.Equal(t, "application/zip", r.Downloads()[0].Filetype())
+				assert.Equal(t, releases[v][2], fmt.Sprintf("%d", r.Downloads()[0].Length()))
+			}
+		} else {
+			// error (unmarshalling failure)
+			assert.Len(t, errors, len(testCase.errors), fmt.Sprintf("%s: errors length mismatch", testCase.path))
+
+			for i, errorMsg := range testCase.errors {
+				err := errors[i]
+				assert.EqualError(t, err, errorMsg)
+			}
+		}
+	}
+
+	// test (successful) [plain text release notes]
+	r, errs := createRelease(0, unmarshalRelease{version: "1.0.0", description: "Description", releaseNotes: "Notes", published: "2016-05-10T10:00:00"})
+	assert.Nil(t, errs)
+	assert.Equal(t, "Description", r.Description())
+	assert.Equal(t, "", r.ReleaseNotesLink())
+
+	r, errs = createRelease(0, unmarshalRelease{version: "1.0.0", releaseNotes: "Notes", published: "2016-05-10T10:00:00"})
+	assert.Nil(t, errs)
+	assert.Equal(t, "Notes", r.Description())
+
+	// test (error) [no source]
+	a := new(Appcast)
+
+	p, errors := a.Unmarshal()
+
+	assert.Len(t, errors, 1)
+	err := errors[0]
+
+	assert.Error(t, err)
+	assert.EqualError(t, err, "no source")
+	assert.Nil(t, p)
+	assert.Nil(t, a.Source())
+}
@@ -0,0 +1,80 @@
+package nuget
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// versions returns the version strings of the provided releases.
+func versions(releases []release.Releaser) []string {
+	var result []string
+	for _, r := range releases {
+		result = append(result, r.Version().String())
+	}
+
+	return result
+}
+
+func TestNewDecoder(t *testing.T) {
+	d := NewDecoder(bytes.NewReader(testdata("unmarshal", "default.xml")))
+	assert.IsType(t, Decoder{}, *d)
+	assert.NotNil(t, d.items)
+}
+
+func TestDecoder_Next(t *testing.T) {
+	// preparations
+	d := NewDecoder(bytes.NewReader(testdata("unmarshal", "default.xml")))
+
+	// test
+	var releases []release.Releaser
+	for {
+		r, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+
+		assert.Nil(t, err)
+		releases = append(releases, r)
+	}
+
+	assert.Len(t, releases, 4)
+	assert.Equal(t, []string{"1.0.0", "1.0.1", "1.1.0", "2.0.0"}, versions(releases))
+
+	// test (release error)
+	d = NewDecoder(bytes.NewReader(testdata("unmarshal", "invalid_pubdate.xml")))
+
+	for i := 0; i < 2; i++ {
+		_, err := d.Next()
+		assert.Nil(t, err)
+	}
+
+	r, err := d.Next()
+	assert.NotNil(t, r)
+	assert.IsType(t, appcaster.Errors{}, err)
+
+	re := err.(appcaster.Errors).ReleaseErrors()[0]
+	assert.Equal(t, 3, re.Index)
+	assert.Equal(t, "publishedDateTime", re.Field)
+	assert.Equal(t, "invalid", re.Value)
+	assert.Equal(t, 55, re.Line)
+	assert.Equal(t, 3, re.Column)
+}
+
+func TestDecode(t *testing.T) {
+	// test
+	var releases []release.Releaser
+	err := Decode(bytes.NewReader(testdata("unmarshal", "default.xml")), func(r release.Releaser, err error) bool {
+		assert.Nil(t, err)
+		releases = append(releases, r)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, releases, 4)
+}
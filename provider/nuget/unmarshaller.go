@@ -0,0 +1,292 @@
+package nuget
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// unmarshalFeed represents a NuGet v2 OData Atom feed itself for the
+// unmarshalling purposes.
+type unmarshalFeed struct {
+	Entries []unmarshalFeedEntry `xml:"entry"`
+}
+
+// unmarshalFeedEntry represents a NuGet v2 OData Atom feed entry for the
+// unmarshalling purposes.
+type unmarshalFeedEntry struct {
+	Title      string                       `xml:"title"`
+	Summary    string                       `xml:"summary"`
+	Updated    string                       `xml:"updated"`
+	Content    unmarshalFeedEntryContent    `xml:"content"`
+	Properties unmarshalFeedEntryProperties `xml:"properties"`
+}
+
+// unmarshalFeedEntryContent represents a NuGet v2 OData Atom feed entry
+// content for the unmarshalling purposes.
+type unmarshalFeedEntryContent struct {
+	Type string `xml:"type,attr"`
+	Src  string `xml:"src,attr"`
+}
+
+// unmarshalFeedEntryProperties represents a NuGet v2 OData Atom feed entry
+// properties for the unmarshalling purposes.
+type unmarshalFeedEntryProperties struct {
+	Version      string `xml:"Version"`
+	Title        string `xml:"Title"`
+	Description  string `xml:"Description"`
+	ReleaseNotes string `xml:"ReleaseNotes"`
+	Published    string `xml:"Published"`
+	IsPrerelease bool   `xml:"IsPrerelease"`
+	PackageSize  int    `xml:"PackageSize"`
+}
+
+// unmarshalRegistration represents a NuGet v3 registration index for the
+// unmarshalling purposes.
+type unmarshalRegistration struct {
+	Pages []unmarshalRegistrationPage `json:"items"`
+}
+
+// unmarshalRegistrationPage represents a NuGet v3 registration page for the
+// unmarshalling purposes.
+type unmarshalRegistrationPage struct {
+	ID    string                      `json:"@id"`
+	Items []unmarshalRegistrationLeaf `json:"items"`
+}
+
+// unmarshalRegistrationLeaf represents a NuGet v3 registration leaf for the
+// unmarshalling purposes.
+type unmarshalRegistrationLeaf struct {
+	CatalogEntry   unmarshalRegistrationCatalogEntry `json:"catalogEntry"`
+	PackageContent string                            `json:"packageContent"`
+}
+
+// unmarshalRegistrationCatalogEntry represents a NuGet v3 registration leaf
+// catalog entry for the unmarshalling purposes.
+type unmarshalRegistrationCatalogEntry struct {
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	ReleaseNotes string `json:"releaseNotes"`
+	Published    string `json:"published"`
+}
+
+// unmarshalRelease represents a single package version from either of the
+// supported feeds for the unmarshalling purposes.
+type unmarshalRelease struct {
+	version      string
+	title        string
+	description  string
+	releaseNotes string
+	published    string
+	isPrerelease bool
+	url          string
+	filetype     string
+	length       int
+}
+
+// reLink matches the release notes that are a link.
+var reLink = regexp.MustCompile(`^https?://\S+$`)
+
+// unmarshal unmarshals the Appcast.source.content from the provided Appcast
+// pointer into its Appcast.releases field.
+func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
+	var items []unmarshalRelease
+	var positions []appcaster.Position
+	var errors []error
+
+	if a.Source() == nil || len(a.Source().Content()) == 0 {
+		return nil, append(errors, fmt.Errorf("no source"))
+	}
+
+	if a.Source().Appcast() == nil {
+		a.Source().SetAppcast(a)
+	}
+
+	content := bytes.TrimSpace(a.Source().Content())
+	if bytes.HasPrefix(content, []byte("{")) {
+		var registration unmarshalRegistration
+
+		err := json.Unmarshal(content, &registration)
+		if err != nil {
+			return nil, append(errors, err)
+		}
+
+		items, errors = registrationReleases(registration)
+	} else {
+		var feed unmarshalFeed
+
+		parsed, repairs, err := a.UnmarshalContent(a.Source().Content(), &feed)
+		if err != nil {
+			return nil, append(errors, append(repairs, err)...)
+		}
+
+		items = feedReleases(feed)
+		positions = appcaster.LocateElements(parsed, "entry")
+		errors = append(errors, repairs...)
+	}
+
+	r, errs := createReleases(items, a.CommentedItems())
+	errs.Locate(positions)
+	errors = append(errors, errs...)
+
+	a.SetReleases(r)
+
+	return a, errors
+}
+
+// feedReleases converts the unmarshalled NuGet v2 OData Atom feed entries into
+// the unmarshalRelease slice.
+func feedReleases(feed unmarshalFeed) []unmarshalRelease {
+	var items []unmarshalRelease
+
+	for _, entry := range feed.Entries {
+		items = append(items, entryRelease(entry))
+	}
+
+	return items
+}
+
+// entryRelease converts the unmarshalled NuGet v2 OData Atom feed entry into
+// the unmarshalRelease. The missing properties fall back to the Atom ones.
+func entryRelease(entry unmarshalFeedEntry) unmarshalRelease {
+	p := entry.Properties
+
+	item := unmarshalRelease{
+		version:      p.Version,
+		title:        p.Title,
+		description:  p.Description,
+		releaseNotes: p.ReleaseNotes,
+		published:    p.Published,
+		isPrerelease: p.IsPrerelease,
+		url:          entry.Content.Src,
+		filetype:     entry.Content.Type,
+		length:       p.PackageSize,
+	}
+
+	if item.title == "" {
+		item.title = entry.Title
+	}
+
+	if item.description == "" {
+		item.description = entry.Summary
+	}
+
+	if item.published == "" {
+		item.published = entry.Updated
+	}
+
+	return item
+}
+
+// registrationReleases converts the unmarshalled NuGet v3 registration leaves
+// into the unmarshalRelease slice. The pages that don't have their leaves
+// inlined are skipped with an error.
+func registrationReleases(registration unmarshalRegistration) ([]unmarshalRelease, []error) {
+	var items []unmarshalRelease
+	var errors []error
+
+	for i, page := range registration.Pages {
+		if len(page.Items) == 0 {
+			errors = append(errors, fmt.Errorf("page #%d (no inlined releases)", i+1))
+			continue
+		}
+
+		for _, leaf := range page.Items {
+			e := leaf.CatalogEntry
+
+			items = append(items, unmarshalRelease{
+				version:      e.Version,
+				title:        e.Title,
+				description:  e.Description,
+				releaseNotes: e.ReleaseNotes,
+				published:    e.Published,
+				url:          leaf.PackageContent,
+				filetype:     "application/zip",
+			})
+		}
+	}
+
+	return items, errors
+}
+
+// createReleases creates a release.Releaseser slice from the unmarshalled
+// package versions sorted by versions in the descending order.
+// The releases of the provided commented items are marked as commented.
+func createReleases(items []unmarshalRelease, commented map[int]bool) (release.Releaseser, appcaster.Errors) {
+	var releases []release.Releaser
+	var errors appcaster.Errors
+
+	for i, item := range items {
+		r, errs := createRelease(i, item)
+		errors = append(errors, errs...)
+
+		// add release
+		if r != nil {
+			r.SetIsCommented(commented[i])
+			releases = append(releases, r)
+		}
+	}
+
+	result := release.NewReleases(releases)
+	result.SortByVersions(release.DESC)
+
+	return result, errors
+}
+
+// createRelease creates a single release from the provided unmarshalled
+// package version and its 0-based index. Returns nil, if the release can't be
+// created.
+func createRelease(i int, item unmarshalRelease) (*release.Release, appcaster.Errors) {
+	var errors appcaster.Errors
+
+	if item.version == "" {
+		return nil, append(errors, appcaster.NewReleaseError(i, "version", "", appcaster.ErrNoVersion))
+	}
+
+	// new release (releases with malformed versions are kept with their version
+	// as a build, so they can still be sorted using the SparkleComparator)
+	r, err := release.NewLenient(item.version, "")
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "version", item.version, err))
+		r.SetBuild(item.version)
+	}
+
+	r.SetTitle(item.title)
+	r.SetDescription(item.description)
+
+	// release notes can be either a link or the notes themselves, which are
+	// used only when there is no description
+	if reLink.MatchString(item.releaseNotes) {
+		r.SetReleaseNotesLink(item.releaseNotes)
+	} else if item.description == "" {
+		r.SetDescription(item.releaseNotes)
+	}
+
+	// publishedDateTime
+	p := release.NewPublishedDateTime()
+
+	err = p.Parse(item.published)
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.published, err))
+	}
+
+	r.SetPublishedDateTime(p)
+
+	// prerelease
+	if item.isPrerelease || (r.Version() != nil && r.Version().Prerelease() != "") {
+		r.SetIsPreRelease(true)
+	}
+
+	// downloads
+	if item.url != "" {
+		d := release.NewDownload(item.url, item.filetype, item.length)
+		r.AddDownload(*d)
+	}
+
+	return r, errors
+}
@@ -29,6 +29,21 @@ const (
 
 	// GitHub represents an Atom feed of the releases generated by the GitHub.
 	GitHub
+
+	// NuGet represents either a NuGet v2 OData Atom feed (as served by the
+	// Chocolatey repositories) or a NuGet v3 registration JSON.
+	NuGet
+
+	// JSONFeed represents a JSON Feed (https://jsonfeed.org/) of the releases.
+	JSONFeed
+
+	// Generic represents a plain RSS 2.0 or Atom feed of the releases that
+	// doesn't follow any of the supported providers. It's used as a fallback.
+	Generic
+
+	// AppStream represents an AppStream metainfo describing the releases of
+	// the Linux desktop applications.
+	AppStream
 )
 
 var providerNames = [...]string{
@@ -36,28 +51,19 @@ var providerNames = [...]string{
 	"Sparkle RSS Feed",
 	"SourceForge RSS Feed",
 	"GitHub Atom Feed",
+	"NuGet Feed",
+	"JSON Feed",
+	"Generic RSS/Atom Feed",
+	"AppStream Metainfo",
 }
 
 // GuessProviderByContent attempts to guess the supported provider from the
-// passed content. By default returns Provider.Unknown.
+// passed content. It returns the provider of the most confident Candidate
+// guessed by GuessProvidersByContent. If none of the supported providers match,
+// but the content is an RSS or Atom feed with items, returns Provider.Generic.
+// By default returns Provider.Unknown.
 func GuessProviderByContent(content []byte) Provider {
-	regexSparkle := regexp.MustCompile(`(?s)(<rss.*xmlns:sparkle)|(?s)(<rss.*<enclosure)`)
-	regexSourceForge := regexp.MustCompile(`(?s)(<rss.*xmlns:sf)|(?s)(<channel.*xmlns:sf)`)
-	regexGitHub := regexp.MustCompile(`(?s)<feed.*<id>tag:github.com`)
-
-	if regexSparkle.Match(content) {
-		return Sparkle
-	}
-
-	if regexSourceForge.Match(content) {
-		return SourceForge
-	}
-
-	if regexGitHub.Match(content) {
-		return GitHub
-	}
-
-	return Unknown
+	return GuessProvidersByContent(content).Best()
 }
 
 // GuessProviderByContentString attempts to guess the supported provider from
@@ -72,6 +78,8 @@ func GuessProviderByContentString(content string) Provider {
 func GuessProviderByUrl(url string) Provider {
 	regexSourceForge := regexp.MustCompile(`.*sourceforge.net/projects/.*/rss`)
 	regexGitHub := regexp.MustCompile(`.*github\.com/(?P<user>.*?)/(?P<repo>.*?)/releases\.atom`)
+	regexAppStream := regexp.MustCompile(`.*\.(metainfo|appdata)\.xml$`)
+	regexNuGet := regexp.MustCompile(`(.*/api/v2/(Packages|FindPackagesById)\b)|(.*/registration[^/]*/.*/index\.json$)`)
 
 	if regexSourceForge.MatchString(url) {
 		return SourceForge
@@ -81,9 +89,33 @@ func GuessProviderByUrl(url string) Provider {
 		return GitHub
 	}
 
+	if regexNuGet.MatchString(url) {
+		return NuGet
+	}
+
+	if regexAppStream.MatchString(url) {
+		return AppStream
+	}
+
 	return Unknown
 }
 
+// GuessProvidersByUrl attempts to guess the supported provider from the passed
+// URL just like GuessProviderByUrl does. Returns a single fully confident
+// Candidate or nil, if none of the supported providers match.
+func GuessProvidersByUrl(url string) Candidates {
+	p := GuessProviderByUrl(url)
+	if p == Unknown {
+		return nil
+	}
+
+	return Candidates{{
+		Provider:   p,
+		Confidence: 1,
+		Reasons:    []string{"the URL matches the provider URL"},
+	}}
+}
+
 // String returns the string representation of the Provider.
 func (p Provider) String() string {
 	return providerNames[p]
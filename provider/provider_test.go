@@ -45,6 +45,21 @@ func getTestdataPath(paths ...string) string {
 
 func TestGuessProviderByContent(t *testing.T) {
 	testCases := map[string]Provider{
+		// AppStream Metainfo
+		"appstream/testdata/unmarshal/default.xml":         AppStream,
+		"appstream/testdata/unmarshal/development.xml":     AppStream,
+		"appstream/testdata/unmarshal/invalid_pubdate.xml": AppStream,
+		"appstream/testdata/unmarshal/invalid_version.xml": AppStream,
+		"appstream/testdata/unmarshal/no_releases.xml":     AppStream,
+		"appstream/testdata/unmarshal/timestamp.xml":       AppStream,
+
+		// Generic RSS/Atom Feed
+		"generic/testdata/unmarshal/atom.xml":        Generic,
+		"generic/testdata/unmarshal/rss.xml":         Generic,
+		"generic/testdata/unmarshal/rss_links.xml":   Generic,
+		"generic/testdata/unmarshal/empty.xml":       Unknown,
+		"generic/testdata/unmarshal/unsupported.xml": Unknown,
+
 		// GitHub Atom Feed
 		"github/testdata/unmarshal/default.xml":         GitHub,
 		"github/testdata/unmarshal/empty.xml":           GitHub,
@@ -53,6 +68,22 @@ func TestGuessProviderByContent(t *testing.T) {
 		"github/testdata/unmarshal/invalid_version.xml": GitHub,
 		"github/testdata/unmarshal/prerelease.xml":      GitHub,
 
+		// JSON Feed
+		"jsonfeed/testdata/unmarshal/default.json":           JSONFeed,
+		"jsonfeed/testdata/unmarshal/empty.json":             JSONFeed,
+		"jsonfeed/testdata/unmarshal/invalid_pubdate.json":   JSONFeed,
+		"jsonfeed/testdata/unmarshal/invalid_version.json":   JSONFeed,
+		"jsonfeed/testdata/unmarshal/prerelease.json":        JSONFeed,
+		"jsonfeed/testdata/unmarshal/without_extension.json": JSONFeed,
+
+		// NuGet Feed
+		"nuget/testdata/unmarshal/default.json":         NuGet,
+		"nuget/testdata/unmarshal/default.xml":          NuGet,
+		"nuget/testdata/unmarshal/empty.xml":            NuGet,
+		"nuget/testdata/unmarshal/invalid_version.json": NuGet,
+		"nuget/testdata/unmarshal/prerelease.json":      NuGet,
+		"nuget/testdata/unmarshal/prerelease.xml":       NuGet,
+
 		// SourceForge RSS Feed
 		"sourceforge/testdata/unmarshal/default.xml":         SourceForge,
 		"sourceforge/testdata/unmarshal/empty.xml":           SourceForge,
@@ -66,6 +97,7 @@ func TestGuessProviderByContent(t *testing.T) {
 		"sparkle/testdata/unmarshal/default_asc.xml":            Sparkle,
 		"sparkle/testdata/unmarshal/default.xml":                Sparkle,
 		"sparkle/testdata/unmarshal/incorrect_namespace.xml":    Sparkle,
+		"sparkle/testdata/unmarshal/maximum_system_version.xml": Sparkle,
 		"sparkle/testdata/unmarshal/multiple_enclosure.xml":     Sparkle,
 		"sparkle/testdata/unmarshal/no_releases.xml":            Sparkle,
 		"sparkle/testdata/unmarshal/single.xml":                 Sparkle,
@@ -87,10 +119,20 @@ func TestGuessProviderByContentString(t *testing.T) {
 
 func TestGuessProviderByUrl(t *testing.T) {
 	testCases := map[string]Provider{
+		// AppStream Metainfo
+		"https://example.com/com.example.App.metainfo.xml": AppStream,
+		"https://example.com/com.example.App.appdata.xml":  AppStream,
+
 		// GitHub Atom Feed
 		"http://github.com/user/repo/releases.atom":  GitHub,
 		"https://github.com/user/repo/releases.atom": GitHub,
 
+		// NuGet Feed
+		"https://community.chocolatey.org/api/v2/Packages()?$filter=Id%20eq%20'git'":   NuGet,
+		"https://community.chocolatey.org/api/v2/FindPackagesById()?id='git'":          NuGet,
+		"https://api.nuget.org/v3/registration5-semver1/newtonsoft.json/index.json":    NuGet,
+		"https://api.nuget.org/v3/registration5-gz-semver2/newtonsoft.json/index.json": NuGet,
+
 		// SourceForge RSS Feed
 		"http://sourceforge.net/projects/name/rss":             SourceForge,
 		"https://sourceforge.net/projects/name/rss":            SourceForge,
@@ -111,9 +153,25 @@ func TestGuessProviderByUrl(t *testing.T) {
 	}
 }
 
+func TestGuessProvidersByUrl(t *testing.T) {
+	// test (successful)
+	candidates := GuessProvidersByUrl("https://github.com/user/repo/releases.atom")
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, GitHub, candidates[0].Provider)
+	assert.Equal(t, 1.0, candidates[0].Confidence)
+	assert.Equal(t, []string{"the URL matches the provider URL"}, candidates[0].Reasons)
+
+	// test (unknown)
+	assert.Nil(t, GuessProvidersByUrl("https://example.com/appcast.xml"))
+}
+
 func TestProvider_String(t *testing.T) {
 	assert.Equal(t, "-", Unknown.String())
 	assert.Equal(t, "Sparkle RSS Feed", Sparkle.String())
 	assert.Equal(t, "SourceForge RSS Feed", SourceForge.String())
 	assert.Equal(t, "GitHub Atom Feed", GitHub.String())
+	assert.Equal(t, "NuGet Feed", NuGet.String())
+	assert.Equal(t, "JSON Feed", JSONFeed.String())
+	assert.Equal(t, "Generic RSS/Atom Feed", Generic.String())
+	assert.Equal(t, "AppStream Metainfo", AppStream.String())
 }
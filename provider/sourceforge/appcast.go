@@ -6,6 +6,8 @@ import "github.com/victorpopkov/go-appcast/appcaster"
 // Appcaster is the interface that wraps the Appcast methods.
 type Appcaster interface {
 	appcaster.Appcaster
+	Marshal() ([]byte, error)
+	Uncomment() error
 }
 
 // Appcast represents the appcast itself.
@@ -33,3 +35,16 @@ func New(src ...interface{}) *Appcast {
 func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
 	return unmarshal(a)
 }
+
+// Marshal marshals the Appcast.releases into the SourceForge RSS feed content.
+// If the Appcast.output is set, the content is also set as its content
+// alongside with the SHA256 checksum.
+func (a *Appcast) Marshal() ([]byte, error) {
+	return marshal(a)
+}
+
+// Uncomment uncomments the commented out items in Appcast.source.content. The
+// releases of these items are marked as commented on unmarshalling.
+func (a *Appcast) Uncomment() error {
+	return a.UncommentItems("item")
+}
@@ -0,0 +1,166 @@
+package sourceforge
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// marshalFeed represents an RSS itself for the marshalling purposes.
+type marshalFeed struct {
+	XMLName    xml.Name           `xml:"rss"`
+	Version    string             `xml:"version,attr"`
+	XmlnsFiles string             `xml:"xmlns:files,attr"`
+	XmlnsMedia string             `xml:"xmlns:media,attr"`
+	XmlnsSf    string             `xml:"xmlns:sf,attr"`
+	Channel    marshalFeedChannel `xml:"channel"`
+}
+
+// marshalFeedChannel represents an RSS channel for the marshalling purposes.
+type marshalFeedChannel struct {
+	Title string            `xml:"title"`
+	Items []marshalFeedItem `xml:"item"`
+}
+
+// marshalFeedItem represents an RSS item for the marshalling purposes.
+type marshalFeedItem struct {
+	Title       marshalCDATA        `xml:"title"`
+	Link        string              `xml:"link,omitempty"`
+	Guid        string              `xml:"guid,omitempty"`
+	PubDate     string              `xml:"pubDate,omitempty"`
+	Description *marshalCDATA       `xml:"description"`
+	ExtraInfo   string              `xml:"files:extra-info,omitempty"`
+	Content     *marshalFeedContent `xml:"media:content"`
+}
+
+// marshalFeedContent represents an RSS item media content for the marshalling
+// purposes.
+type marshalFeedContent struct {
+	Type     string           `xml:"type,attr,omitempty"`
+	URL      string           `xml:"url,attr"`
+	Filesize int              `xml:"filesize,attr,omitempty"`
+	Hash     *marshalFeedHash `xml:"media:hash"`
+}
+
+// marshalFeedHash represents an RSS item media content hash for the
+// marshalling purposes.
+type marshalFeedHash struct {
+	Algo  string `xml:"algo,attr"`
+	Value string `xml:",chardata"`
+}
+
+// marshalCDATA represents a character data wrapped in the CDATA section for the
+// marshalling purposes.
+type marshalCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// marshal marshals the Appcast.releases from the provided Appcast pointer into
+// the SourceForge RSS feed content.
+//
+// SourceForge lists files instead of releases, so each release download becomes
+// a separate item. The item title is the file path which includes the version,
+// for example: "/2.0.0/app_2.0.0.dmg".
+func marshal(a *Appcast) ([]byte, error) {
+	if a.Releases() == nil {
+		return nil, fmt.Errorf("no releases")
+	}
+
+	feed := marshalFeed{
+		Version:    "2.0",
+		XmlnsFiles: "https://sourceforge.net/api/files.rdf#",
+		XmlnsMedia: "http://video.search.yahoo.com/mrss/",
+		XmlnsSf:    "https://sourceforge.net/api/sfelements.rdf#",
+	}
+
+	for _, r := range a.Releases().Filtered() {
+		downloads := r.Downloads()
+		if len(downloads) == 0 {
+			feed.Channel.Items = append(feed.Channel.Items, newMarshalFeedItem(r, nil))
+			continue
+		}
+
+		for i := range downloads {
+			feed.Channel.Items = append(feed.Channel.Items, newMarshalFeedItem(r, &downloads[i]))
+		}
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.Write(content)
+	buf.WriteString("\n")
+
+	content = buf.Bytes()
+
+	if a.Output() != nil {
+		a.Output().SetContent(content)
+		a.Output().GenerateChecksum(appcaster.SHA256)
+		a.Output().SetAppcast(a)
+	}
+
+	return content, nil
+}
+
+// newMarshalFeedItem returns a new marshalFeedItem created from the provided
+// release and its download. The download can be nil.
+func newMarshalFeedItem(r release.Releaser, d *release.Download) marshalFeedItem {
+	item := marshalFeedItem{
+		Title: marshalCDATA{title(r, d)},
+	}
+
+	if r.Description() != "" {
+		item.Description = &marshalCDATA{r.Description()}
+	}
+
+	if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+		item.PubDate = p.Time().Format(time.RFC1123Z)
+	}
+
+	if d != nil {
+		item.Link = d.Url()
+		item.Guid = d.Url()
+		item.ExtraInfo = d.ExtraInfo()
+		item.Content = &marshalFeedContent{
+			Type:     d.Filetype(),
+			URL:      d.Url(),
+			Filesize: d.Length(),
+		}
+
+		if d.Md5() != "" {
+			item.Content.Hash = &marshalFeedHash{Algo: "md5", Value: d.Md5()}
+		}
+	}
+
+	return item
+}
+
+// title returns the SourceForge item title for the provided release and its
+// download. The release title is kept when it's already a file path.
+// Otherwise, the path is created from the version and the download filename.
+func title(r release.Releaser, d *release.Download) string {
+	if strings.HasPrefix(r.Title(), "/") {
+		return r.Title()
+	}
+
+	version := r.Build()
+	if r.Version() != nil {
+		version = r.Version().Original()
+	}
+
+	if d == nil {
+		return "/" + version
+	}
+
+	return "/" + version + "/" + path.Base(strings.TrimSuffix(d.Url(), "/download"))
+}
@@ -1,7 +1,6 @@
 package sourceforge
 
 import (
-	"encoding/xml"
 	"fmt"
 
 	"github.com/victorpopkov/go-appcast/appcaster"
@@ -18,6 +17,7 @@ type unmarshalFeedItem struct {
 	Title       unmarshalFeedItemTitle       `xml:"title"`
 	Description unmarshalFeedItemDescription `xml:"description"`
 	Content     unmarshalFeedItemContent     `xml:"content"`
+	ExtraInfo   string                       `xml:"extra-info"`
 	PubDate     string                       `xml:"pubDate"`
 }
 
@@ -55,61 +55,76 @@ func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
 		a.Source().SetAppcast(a)
 	}
 
-	err := xml.Unmarshal(a.Source().Content(), &feed)
+	content, repairs, err := a.UnmarshalContent(a.Source().Content(), &feed)
 	if err != nil {
-		return nil, append(errors, err)
+		return nil, append(errors, append(repairs, err)...)
 	}
 
-	r, errors := createReleases(feed)
+	r, errs := createReleases(feed, a.CommentedItems())
+	errs.Locate(appcaster.LocateElements(content, "item"))
+	errs = append(repairs, errs...)
 
 	a.SetReleases(r)
 
-	return a, errors
+	return a, errs
 }
 
 // createReleases creates a release.Releaseser slice from the unmarshalled feed.
-func createReleases(feed unmarshalFeed) (release.Releaseser, []error) {
+// The releases of the provided commented items are marked as commented.
+func createReleases(feed unmarshalFeed, commented map[int]bool) (release.Releaseser, appcaster.Errors) {
 	var items []release.Releaser
-	var errors []error
+	var errors appcaster.Errors
 
 	for i, item := range feed.Items {
-		// extract version
-		versions, err := appcaster.ExtractSemanticVersions(item.Title.Chardata)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (no version)", i+1))
-			continue
+		r, errs := createRelease(i, item)
+		errors = append(errors, errs...)
+
+		// add release
+		if r != nil {
+			r.SetIsCommented(commented[i])
+			items = append(items, r)
 		}
+	}
 
-		// new release
-		r, _ := release.New(versions[0], "")
+	return release.NewReleases(items), errors
+}
 
-		r.SetTitle(item.Title.Chardata)
-		r.SetDescription(item.Description.Chardata)
+// createRelease creates a single release from the provided unmarshalled item
+// and its 0-based index. Returns nil, if the release can't be created.
+func createRelease(i int, item unmarshalFeedItem) (*release.Release, appcaster.Errors) {
+	var errors appcaster.Errors
 
-		// publishedDateTime
-		p := release.NewPublishedDateTime()
+	// extract version
+	versions, err := appcaster.ExtractSemanticVersions(item.Title.Chardata)
+	if err != nil {
+		return nil, append(errors, appcaster.NewReleaseError(i, "version", item.Title.Chardata, appcaster.ErrNoVersion))
+	}
 
-		err = p.Parse(item.PubDate)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (%s)", i+1, err.Error()))
-		}
+	// new release
+	r, _ := release.NewLenient(versions[0], "")
 
-		r.SetPublishedDateTime(p)
+	r.SetTitle(item.Title.Chardata)
+	r.SetDescription(item.Description.Chardata)
 
-		// prerelease
-		if r.Version().Prerelease() != "" {
-			r.SetIsPreRelease(true)
-		}
+	// publishedDateTime
+	p := release.NewPublishedDateTime()
 
-		// downloads
-		d := release.NewDownload(item.Content.URL, item.Content.Type, item.Content.Filesize)
-		r.AddDownload(*d)
+	err = p.Parse(item.PubDate)
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.PubDate, err))
+	}
 
-		// add release
-		if r != nil {
-			items = append(items, r)
-		}
+	r.SetPublishedDateTime(p)
+
+	// prerelease
+	if r.Version().Prerelease() != "" {
+		r.SetIsPreRelease(true)
 	}
 
-	return release.NewReleases(items), errors
+	// downloads
+	d := release.NewDownload(item.Content.URL, item.Content.Type, item.Content.Filesize)
+	d.SetExtraInfo(item.ExtraInfo)
+	r.AddDownload(*d)
+
+	return r, errors
 }
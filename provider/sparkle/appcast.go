@@ -1,18 +1,15 @@
 // Package sparkle adds support for the Sparkle Framework releases RSS feed.
 package sparkle
 
-import (
-	"fmt"
-	"regexp"
-
-	"github.com/victorpopkov/go-appcast/appcaster"
-)
+import "github.com/victorpopkov/go-appcast/appcaster"
 
 // Appcaster is the interface that wraps the Appcast methods.
 type Appcaster interface {
 	appcaster.Appcaster
+	Marshal() ([]byte, error)
 	Channel() *Channel
 	SetChannel(channel *Channel)
+	Uncomment() error
 }
 
 // Appcast represents the appcast itself.
@@ -51,18 +48,17 @@ func (a *Appcast) Unmarshal() (appcaster.Appcaster, []error) {
 	return unmarshal(a)
 }
 
-// Uncomment uncomments XML tags in Appcast.source.content.
-func (a *Appcast) Uncomment() error {
-	if a.Source() == nil || len(a.Source().Content()) == 0 {
-		return fmt.Errorf("no source")
-	}
-
-	regex := regexp.MustCompile(`(<!--([[:space:]]*)?)|(([[:space:]]*)?-->)`)
-	if regex.Match(a.Source().Content()) {
-		a.Source().SetContent(regex.ReplaceAll(a.Source().Content(), []byte("")))
-	}
+// Marshal marshals the Appcast.releases and Appcast.channel into the Sparkle
+// RSS feed content. If the Appcast.output is set, the content is also set as
+// its content alongside with the SHA256 checksum.
+func (a *Appcast) Marshal() ([]byte, error) {
+	return marshal(a)
+}
 
-	return nil
+// Uncomment uncomments the commented out items in Appcast.source.content. The
+// releases of these items are marked as commented on unmarshalling.
+func (a *Appcast) Uncomment() error {
+	return a.UncommentItems("item")
 }
 
 // Channel is a Appcast.channel getter.
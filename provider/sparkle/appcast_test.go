@@ -294,8 +294,40 @@ func TestAppcast_Unmarshal(t *testing.T) {
 		}
 	}
 
+	// test (successful) [maximum system version]
+	a := newTestAppcast("unmarshal", "maximum_system_version.xml")
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, "10.10", a.Releases().First().MinimumSystemVersion())
+	assert.Equal(t, "10.15", a.Releases().First().MaximumSystemVersion())
+
+	// test (successful) [EdDSA signature]
+	a = newTestAppcast("unmarshal", "ed_signature.xml")
+	a.SetOutput(new(appcaster.Output))
+
+	_, errors = a.Unmarshal()
+	assert.Nil(t, errors)
+
+	d := a.Releases().First().Downloads()[0]
+	assert.Equal(t, "", d.DsaSignature())
+	assert.Equal(t, "7cLALFUHSwvEJWSkV8aMreoBe4fhRa4FncC5NoThKxwThL6FDR7hTiPJh1fo2uagnPogisnQsgFgq6mGkt2RBw==", d.EdSignature())
+
+	content, marshalErr := a.Marshal()
+	assert.Nil(t, marshalErr)
+	assert.Contains(t, string(content), `sparkle:edSignature="7cLALFUHSwvEJWSkV8aMreoBe4fhRa4FncC5NoThKxwThL6FDR7hTiPJh1fo2uagnPogisnQsgFgq6mGkt2RBw=="`)
+
+	// test (error) [malformed version with build]
+	a = newTestAppcast("unmarshal", "invalid_version.xml")
+
+	_, errors = a.Unmarshal()
+	assert.Len(t, errors, 1)
+	assert.Equal(t, 4, a.Releases().Len())
+	assert.Nil(t, a.Releases().Filtered()[1].Version())
+	assert.Equal(t, "110", a.Releases().Filtered()[1].Build())
+
 	// test (error) [no source]
-	a := new(Appcast)
+	a = new(Appcast)
 
 	p, errors := a.Unmarshal()
 
@@ -309,20 +341,130 @@ func TestAppcast_Unmarshal(t *testing.T) {
 	assert.Nil(t, a.channel)
 }
 
+func TestAppcast_Marshal(t *testing.T) {
+	testCases := map[string]string{
+		"default.xml":    "default.xml",
+		"prerelease.xml": "prerelease.xml",
+	}
+
+	// test (successful)
+	for unmarshalPath, marshalPath := range testCases {
+		// preparations
+		a := newTestAppcast("unmarshal", unmarshalPath)
+		a.SetOutput(new(appcaster.Output))
+
+		_, errors := a.Unmarshal()
+		assert.Nil(t, errors)
+
+		// test
+		content, err := a.Marshal()
+		assert.Nil(t, err)
+		assert.Equal(t, string(testdata("marshal", marshalPath)), string(content), marshalPath)
+		assert.Equal(t, content, a.Output().Content())
+		assert.NotNil(t, a.Output().Checksum())
+		assert.Equal(t, a, a.Output().Appcast())
+	}
+
+	// test (successful) [round trip]
+	a := newTestAppcast("unmarshal", "default.xml")
+	a.Unmarshal()
+
+	content, _ := a.Marshal()
+	a = newTestAppcast("marshal", "default.xml")
+	a.Source().SetContent(content)
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, 4, a.Releases().Len())
+	assert.Equal(t, "2.0.0", a.Releases().First().Version().String())
+	assert.Equal(t, "200", a.Releases().First().Build())
+	assert.Equal(t, "App", a.Channel().Title)
+
+	// test (error) [no releases]
+	a = new(Appcast)
+
+	content, err := a.Marshal()
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "no releases")
+}
+
+func TestAppcast_UnmarshalReleaseErrors(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "invalid_version.xml")
+
+	// test
+	_, errs := a.Unmarshal()
+	assert.Len(t, errs, 1)
+
+	re, ok := errs[0].(*appcaster.ReleaseError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, re.Index)
+	assert.Equal(t, "version", re.Field)
+	assert.Equal(t, "invalid", re.Value)
+	assert.Equal(t, 15, re.Line)
+	assert.Equal(t, 5, re.Column)
+	assert.EqualError(t, re.Err, "malformed version: invalid")
+	assert.EqualError(t, re, "release #2 (malformed version: invalid)")
+}
+
+func TestAppcast_UnmarshalLegacyCharset(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "legacy_charset.xml")
+
+	// test
+	p, errs := a.Unmarshal()
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "Café", p.(*Appcast).Channel().Title)
+	assert.Equal(t, 1, a.Releases().Len())
+	assert.Equal(t, "Release 2.0.0 – Zürich", a.Releases().First().Description())
+}
+
+func TestAppcast_UnmarshalLenient(t *testing.T) {
+	// preparations
+	a := newTestAppcast("unmarshal", "malformed.xml")
+
+	// test
+	p, errs := a.Unmarshal()
+	assert.Nil(t, p)
+	assert.Len(t, errs, 1)
+
+	// test (lenient)
+	a = newTestAppcast("unmarshal", "malformed.xml")
+	a.SetLenient(true)
+
+	p, errs = a.Unmarshal()
+	assert.NotNil(t, p)
+	assert.Len(t, errs, 3)
+	for _, err := range errs {
+		assert.IsType(t, &appcaster.Repair{}, err)
+	}
+
+	assert.EqualError(t, errs[0], "line 4: unescaped \"&\" escaped")
+	assert.EqualError(t, errs[1], "line 10: unbalanced markup of the \"description\" element wrapped in CDATA")
+	assert.EqualError(t, errs[2], "line 13: unescaped \"&\" escaped")
+
+	assert.Equal(t, "App & Tools", p.(*Appcast).Channel().Title)
+	assert.Equal(t, 2, a.Releases().Len())
+
+	r := a.Releases().First()
+	assert.Equal(t, "<p>Fixes&nbsp;and improvements<br></p>", r.Description())
+	assert.Equal(t, "https://example.com/app_2.0.0.dmg?a=1&b=2", r.Downloads()[0].Url())
+}
+
 func TestAppcast_Uncomment(t *testing.T) {
 	testCases := map[string][]int{
 		"attributes_as_elements.xml": nil,
+		"commented_items.xml":        {8, 14, 15, 21},
 		"default_asc.xml":            nil,
 		"default.xml":                nil,
 		"incorrect_namespace.xml":    nil,
 		"multiple_enclosure.xml":     nil,
 		"single.xml":                 nil,
-		"with_comments.xml":          {13, 20},
+		"with_comments.xml":          nil,
 		"without_namespaces.xml":     nil,
 	}
 
-	regexCommentStart := regexp.MustCompile(`<!--([[:space:]]*)?<`)
-	regexCommentEnd := regexp.MustCompile(`>([[:space:]]*)?-->`)
+	regexComment := regexp.MustCompile(`<!--|-->`)
 
 	// test (successful)
 	for filename, commentLines := range testCases {
@@ -332,8 +474,7 @@ func TestAppcast_Uncomment(t *testing.T) {
 		// before
 		for _, commentLine := range commentLines {
 			line, _ := getLine(commentLine, a.Source().Content())
-			check := regexCommentStart.MatchString(line) && regexCommentEnd.MatchString(line)
-			assert.True(t, check, fmt.Sprintf("\"%s\" doesn't have a commented out line", filename))
+			assert.True(t, regexComment.MatchString(line), fmt.Sprintf("\"%s\" doesn't have a commented out line", filename))
 		}
 
 		err := a.Uncomment()
@@ -343,13 +484,33 @@ func TestAppcast_Uncomment(t *testing.T) {
 
 		for _, commentLine := range commentLines {
 			line, _ := getLine(commentLine, a.Source().Content())
-			check := regexCommentStart.MatchString(line) && regexCommentEnd.MatchString(line)
-			assert.False(t, check, fmt.Sprintf("\"%s\" didn't uncomment a \"%d\" line", filename, commentLine))
+			assert.False(t, regexComment.MatchString(line), fmt.Sprintf("\"%s\" didn't uncomment a \"%d\" line", filename, commentLine))
 		}
 	}
 
+	// test (successful) [commented releases]
+	a := newTestAppcast("unmarshal", "commented_items.xml")
+	a.Uncomment()
+
+	_, errors := a.Unmarshal()
+	assert.Nil(t, errors)
+	assert.Equal(t, map[int]bool{0: true, 1: true}, a.CommentedItems())
+	assert.True(t, a.Releases().Filtered()[0].IsCommented())
+	assert.True(t, a.Releases().Filtered()[1].IsCommented())
+	assert.False(t, a.Releases().Filtered()[2].IsCommented())
+	assert.Equal(t, "2.0.0", a.Releases().Filtered()[0].Version().String())
+	assert.Equal(t, "", a.Releases().Filtered()[2].ReleaseNotesLink())
+
+	// test (successful) [commented markup inside releases]
+	a = newTestAppcast("unmarshal", "with_comments.xml")
+	content := a.Source().Content()
+	a.Uncomment()
+
+	assert.Equal(t, content, a.Source().Content())
+	assert.Empty(t, a.CommentedItems())
+
 	// test (error) [no source]
-	a := new(Appcast)
+	a = new(Appcast)
 
 	err := a.Uncomment()
 	assert.Error(t, err)
@@ -0,0 +1,56 @@
+package sparkle
+
+import (
+	"io"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+	"github.com/victorpopkov/go-appcast/release"
+)
+
+// Decoder decodes the Sparkle RSS Feed releases one by one from a stream.
+//
+// Unlike the Appcast.Unmarshal, it never holds the whole feed in memory, so it
+// suits the very large feeds. Only the releases are decoded.
+type Decoder struct {
+	items *appcaster.ItemDecoder
+}
+
+// NewDecoder returns a new Decoder instance pointer reading from the provided
+// reader.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		items: appcaster.NewItemDecoder(r, "item"),
+	}
+}
+
+// Next decodes the next release. Returns io.EOF, if there are no more
+// releases.
+//
+// The release errors are returned alongside the release as appcaster.Errors.
+// The release is nil, if it can't be created at all, but the decoding can
+// continue. Any other error is fatal.
+func (d *Decoder) Next() (release.Releaser, error) {
+	var item unmarshalFeedItem
+
+	i, position, err := d.items.Next(&item)
+	if err != nil {
+		return nil, err
+	}
+
+	r, errs := createRelease(i, item)
+	errs.SetPosition(position)
+
+	if r == nil {
+		return nil, errs.Err()
+	}
+
+	return r, errs.Err()
+}
+
+// Decode decodes the releases from the provided reader one by one and passes
+// each of them to the provided function alongside with its errors. The
+// decoding stops when the function returns false. Returns the fatal decoding
+// error, if any.
+func Decode(r io.Reader, fn func(r release.Releaser, err error) bool) error {
+	return appcaster.DecodeEach(NewDecoder(r), fn)
+}
@@ -0,0 +1,159 @@
+package sparkle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Namespace represents the Sparkle XML namespace used for marshalling.
+const Namespace = "http://www.andymatuschak.org/xml-namespaces/sparkle"
+
+// marshalFeed represents an RSS itself for the marshalling purposes.
+type marshalFeed struct {
+	XMLName      xml.Name           `xml:"rss"`
+	Version      string             `xml:"version,attr"`
+	XmlnsSparkle string             `xml:"xmlns:sparkle,attr"`
+	Channel      marshalFeedChannel `xml:"channel"`
+}
+
+// marshalFeedChannel represents an RSS channel for the marshalling purposes.
+type marshalFeedChannel struct {
+	Title       string            `xml:"title"`
+	Link        string            `xml:"link,omitempty"`
+	Description *marshalCDATA     `xml:"description"`
+	Language    string            `xml:"language,omitempty"`
+	Items       []marshalFeedItem `xml:"item"`
+}
+
+// marshalFeedItem represents a single RSS item for the marshalling purposes.
+type marshalFeedItem struct {
+	Title                string                `xml:"title"`
+	Description          *marshalCDATA         `xml:"description"`
+	PubDate              string                `xml:"pubDate,omitempty"`
+	ReleaseNotesLink     string                `xml:"sparkle:releaseNotesLink,omitempty"`
+	MinimumSystemVersion string                `xml:"sparkle:minimumSystemVersion,omitempty"`
+	MaximumSystemVersion string                `xml:"sparkle:maximumSystemVersion,omitempty"`
+	Version              string                `xml:"sparkle:version,omitempty"`
+	ShortVersionString   string                `xml:"sparkle:shortVersionString,omitempty"`
+	Enclosure            *marshalFeedEnclosure `xml:"enclosure"`
+}
+
+// marshalFeedEnclosure represents a single RSS item enclosure for the
+// marshalling purposes.
+type marshalFeedEnclosure struct {
+	Version            string `xml:"sparkle:version,attr,omitempty"`
+	ShortVersionString string `xml:"sparkle:shortVersionString,attr,omitempty"`
+	URL                string `xml:"url,attr"`
+	Length             int    `xml:"length,attr,omitempty"`
+	Type               string `xml:"type,attr,omitempty"`
+	DsaSignature       string `xml:"sparkle:dsaSignature,attr,omitempty"`
+	EdSignature        string `xml:"sparkle:edSignature,attr,omitempty"`
+	MD5Sum             string `xml:"sparkle:md5Sum,attr,omitempty"`
+}
+
+// marshalCDATA represents a character data wrapped in the CDATA section for the
+// marshalling purposes.
+type marshalCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// newMarshalCDATA returns a new marshalCDATA instance pointer holding the
+// provided value. Returns nil, if the value is empty.
+func newMarshalCDATA(value string) *marshalCDATA {
+	if value == "" {
+		return nil
+	}
+
+	return &marshalCDATA{value}
+}
+
+// marshal marshals the Appcast.releases and Appcast.channel from the provided
+// Appcast pointer into the Sparkle RSS feed content.
+//
+// Sparkle supports only a single enclosure per item, so only the first release
+// download is used. The versions are stored as the enclosure attributes or as
+// the item elements, when there are no downloads.
+func marshal(a *Appcast) ([]byte, error) {
+	if a.Releases() == nil {
+		return nil, fmt.Errorf("no releases")
+	}
+
+	feed := marshalFeed{
+		Version:      "2.0",
+		XmlnsSparkle: Namespace,
+	}
+
+	if a.channel != nil {
+		feed.Channel.Title = a.channel.Title
+		feed.Channel.Link = a.channel.Link
+		feed.Channel.Description = newMarshalCDATA(a.channel.Description)
+		feed.Channel.Language = a.channel.Language
+	}
+
+	for _, r := range a.Releases().Filtered() {
+		version := ""
+		if r.Version() != nil {
+			version = r.Version().Original()
+		}
+
+		build := r.Build()
+		if build == "" {
+			build = version
+		}
+
+		item := marshalFeedItem{
+			Title:                r.Title(),
+			Description:          newMarshalCDATA(r.Description()),
+			ReleaseNotesLink:     r.ReleaseNotesLink(),
+			MinimumSystemVersion: r.MinimumSystemVersion(),
+			MaximumSystemVersion: r.MaximumSystemVersion(),
+		}
+
+		if p := r.PublishedDateTime(); p != nil && p.Time() != nil {
+			item.PubDate = p.Time().Format(time.RFC1123Z)
+		}
+
+		if downloads := r.Downloads(); len(downloads) > 0 {
+			d := downloads[0]
+			item.Enclosure = &marshalFeedEnclosure{
+				Version:            build,
+				ShortVersionString: version,
+				URL:                d.Url(),
+				Length:             d.Length(),
+				Type:               d.Filetype(),
+				DsaSignature:       d.DsaSignature(),
+				EdSignature:        d.EdSignature(),
+				MD5Sum:             d.Md5(),
+			}
+		} else {
+			item.Version = build
+			item.ShortVersionString = version
+		}
+
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.Write(content)
+	buf.WriteString("\n")
+
+	content = buf.Bytes()
+
+	if a.Output() != nil {
+		a.Output().SetContent(content)
+		a.Output().GenerateChecksum(appcaster.SHA256)
+		a.Output().SetAppcast(a)
+	}
+
+	return content, nil
+}
@@ -1,7 +1,6 @@
 package sparkle
 
 import (
-	"encoding/xml"
 	"fmt"
 
 	"github.com/victorpopkov/go-appcast/appcaster"
@@ -31,6 +30,7 @@ type unmarshalFeedItem struct {
 	PubDate              string                 `xml:"pubDate"`
 	ReleaseNotesLink     string                 `xml:"releaseNotesLink"`
 	MinimumSystemVersion string                 `xml:"minimumSystemVersion"`
+	MaximumSystemVersion string                 `xml:"maximumSystemVersion"`
 	Enclosure            unmarshalFeedEnclosure `xml:"enclosure"`
 	Version              string                 `xml:"version"`
 	ShortVersionString   string                 `xml:"shortVersionString"`
@@ -40,6 +40,7 @@ type unmarshalFeedItem struct {
 // unmarshalling purposes.
 type unmarshalFeedEnclosure struct {
 	DsaSignature       string `xml:"dsaSignature,attr"`
+	EdSignature        string `xml:"edSignature,attr"`
 	MD5Sum             string `xml:"md5Sum,attr"`
 	Version            string `xml:"version,attr"`
 	ShortVersionString string `xml:"shortVersionString,attr"`
@@ -62,12 +63,14 @@ func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
 		a.Source().SetAppcast(a)
 	}
 
-	err := xml.Unmarshal(a.Source().Content(), &feed)
+	content, repairs, err := a.UnmarshalContent(a.Source().Content(), &feed)
 	if err != nil {
-		return nil, append(errors, err)
+		return nil, append(errors, append(repairs, err)...)
 	}
 
-	r, errors := createReleases(feed)
+	r, errs := createReleases(feed, a.CommentedItems())
+	errs.Locate(appcaster.LocateElements(content, "item"))
+	errs = append(repairs, errs...)
 
 	a.SetReleases(r)
 
@@ -78,73 +81,90 @@ func unmarshal(a *Appcast) (appcaster.Appcaster, []error) {
 		Language:    feed.Channel.Language,
 	}
 
-	return a, errors
+	return a, errs
 }
 
 // createReleases creates a release.Releaseser slice from the unmarshalled feed.
-func createReleases(feed unmarshalFeed) (release.Releaseser, []error) {
-	var version, build string
+// The releases of the provided commented items are marked as commented.
+func createReleases(feed unmarshalFeed, commented map[int]bool) (release.Releaseser, appcaster.Errors) {
 	var items []release.Releaser
-	var errors []error
+	var errors appcaster.Errors
 
 	for i, item := range feed.Channel.Items {
-		if item.Enclosure.ShortVersionString == "" && item.ShortVersionString != "" {
-			version = item.ShortVersionString
-		} else {
-			version = item.Enclosure.ShortVersionString
-		}
+		r, errs := createRelease(i, item)
+		errors = append(errors, errs...)
 
-		if item.Enclosure.Version == "" && item.Version != "" {
-			build = item.Version
-		} else {
-			build = item.Enclosure.Version
+		// add release
+		if r != nil {
+			r.SetIsCommented(commented[i])
+			items = append(items, r)
 		}
+	}
 
-		if version == "" && build == "" {
-			errors = append(errors, fmt.Errorf("release #%d (no version)", i+1))
-			continue
-		} else if version == "" && build != "" {
-			version = build
-		}
+	return release.NewReleases(items), errors
+}
 
-		// new release
-		r, err := release.New(version, build)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (%s)", i+1, err.Error()))
-			continue
-		}
+// createRelease creates a single release from the provided unmarshalled item
+// and its 0-based index. Returns nil, if the release can't be created.
+func createRelease(i int, item unmarshalFeedItem) (*release.Release, appcaster.Errors) {
+	var version, build string
+	var errors appcaster.Errors
+
+	if item.Enclosure.ShortVersionString == "" && item.ShortVersionString != "" {
+		version = item.ShortVersionString
+	} else {
+		version = item.Enclosure.ShortVersionString
+	}
 
-		r.SetTitle(item.Title)
-		r.SetDescription(item.Description)
-		r.SetReleaseNotesLink(item.ReleaseNotesLink)
-		r.SetMinimumSystemVersion(item.MinimumSystemVersion)
+	if item.Enclosure.Version == "" && item.Version != "" {
+		build = item.Version
+	} else {
+		build = item.Enclosure.Version
+	}
 
-		// publishedDateTime
-		p := release.NewPublishedDateTime()
+	if version == "" && build == "" {
+		return nil, append(errors, appcaster.NewReleaseError(i, "version", "", appcaster.ErrNoVersion))
+	} else if version == "" && build != "" {
+		version = build
+	}
 
-		err = p.Parse(item.PubDate)
-		if err != nil {
-			errors = append(errors, fmt.Errorf("release #%d (%s)", i+1, err.Error()))
+	// new release (releases with malformed versions are kept when they have a
+	// build, so they can still be sorted using the SparkleComparator)
+	r, err := release.NewLenient(version, build)
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "version", version, err))
+		if build == "" {
+			return nil, errors
 		}
+	}
 
-		r.SetPublishedDateTime(p)
+	r.SetTitle(item.Title)
+	r.SetDescription(item.Description)
+	r.SetReleaseNotesLink(item.ReleaseNotesLink)
+	r.SetMinimumSystemVersion(item.MinimumSystemVersion)
+	r.SetMaximumSystemVersion(item.MaximumSystemVersion)
 
-		// prerelease
-		if r.Version().Prerelease() != "" {
-			r.SetIsPreRelease(true)
-		}
+	// publishedDateTime
+	p := release.NewPublishedDateTime()
 
-		// downloads
-		e := item.Enclosure
-		d := release.NewDownload(e.URL, e.Type, e.Length, e.DsaSignature, e.MD5Sum)
+	err = p.Parse(item.PubDate)
+	if err != nil {
+		errors = append(errors, appcaster.NewReleaseError(i, "publishedDateTime", item.PubDate, err))
+	}
 
-		r.AddDownload(*d)
+	r.SetPublishedDateTime(p)
 
-		// add release
-		if r != nil {
-			items = append(items, r)
-		}
+	// prerelease
+	if r.Version() != nil && r.Version().Prerelease() != "" {
+		r.SetIsPreRelease(true)
 	}
 
-	return release.NewReleases(items), errors
+	// downloads
+	e := item.Enclosure
+	d := release.NewDownload(e.URL, e.Type, e.Length, e.DsaSignature, e.MD5Sum)
+	d.SetEdSignature(e.EdSignature)
+
+	r.AddDownload(*d)
+
+	return r, errors
 }
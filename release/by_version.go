@@ -1,6 +1,7 @@
 package release
 
 // ByVersion implements sort.Interface for the []Releaser based Version field.
+// Releases are compared using the SemanticComparator.
 type ByVersion []Releaser
 
 func (a ByVersion) Len() int {
@@ -12,5 +13,24 @@ func (a ByVersion) Swap(i, j int) {
 }
 
 func (a ByVersion) Less(i, j int) bool {
-	return a[i].Version().LessThan(a[j].Version())
+	return SemanticComparator(a[i], a[j]) < 0
+}
+
+// byComparator implements sort.Interface for the []Releaser using the
+// provided Comparator.
+type byComparator struct {
+	releases []Releaser
+	compare  Comparator
+}
+
+func (a byComparator) Len() int {
+	return len(a.releases)
+}
+
+func (a byComparator) Swap(i, j int) {
+	a.releases[i], a.releases[j] = a.releases[j], a.releases[i]
+}
+
+func (a byComparator) Less(i, j int) bool {
+	return a.compare(a.releases[i], a.releases[j]) < 0
 }
@@ -49,3 +49,16 @@ func TestByVersion_Less(t *testing.T) {
 	assert.True(t, ByVersion(testReleases).Less(0, 1))
 	assert.True(t, ByVersion(testReleases).Less(1, 2))
 }
+
+func TestByVersion_Less_WithoutVersions(t *testing.T) {
+	// preparations
+	r1, _ := NewLenient("invalid", "100")
+	r2, _ := NewLenient("invalid", "99")
+	r3, _ := New("1.0.0", "")
+	testReleases := []Releaser{r1, r2, r3}
+
+	// test
+	assert.False(t, ByVersion(testReleases).Less(0, 1))
+	assert.True(t, ByVersion(testReleases).Less(1, 0))
+	assert.True(t, ByVersion(testReleases).Less(0, 2))
+}
@@ -0,0 +1,74 @@
+package release
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Cadence represents the release cadence summary computed from the intervals
+// between the consecutive releases.
+type Cadence struct {
+	// Intervals specifies the number of intervals between the consecutive
+	// releases the summary has been computed from.
+	Intervals int
+
+	// Mean specifies the mean interval between the releases.
+	Mean time.Duration
+
+	// Median specifies the median interval between the releases.
+	Median time.Duration
+
+	// First specifies the published datetime of the earliest release.
+	First time.Time
+
+	// Last specifies the published datetime of the latest release.
+	Last time.Time
+}
+
+// Cadence computes the release Cadence from the Releases.filtered published
+// datetimes. Releases without a published datetime are ignored. Returns an
+// error, if there are less than 2 dated releases.
+func (r *Releases) Cadence() (*Cadence, error) {
+	var times []time.Time
+
+	for _, release := range r.filtered {
+		if isDated(release) {
+			times = append(times, *release.PublishedDateTime().Time())
+		}
+	}
+
+	if len(times) < 2 {
+		return nil, fmt.Errorf("not enough dated releases")
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	intervals := make([]time.Duration, len(times)-1)
+	var total time.Duration
+
+	for i := 1; i < len(times); i++ {
+		intervals[i-1] = times[i].Sub(times[i-1])
+		total += intervals[i-1]
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+
+	n := len(intervals)
+	median := intervals[n/2]
+	if n%2 == 0 {
+		median = (intervals[n/2-1] + intervals[n/2]) / 2
+	}
+
+	return &Cadence{
+		Intervals: n,
+		Mean:      total / time.Duration(n),
+		Median:    median,
+		First:     times[0],
+		Last:      times[len(times)-1],
+	}, nil
+}
@@ -0,0 +1,37 @@
+package release
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleases_Cadence(t *testing.T) {
+	// test (successful) [odd]
+	r := newTestDatedReleases("2016-05-13", "invalid", "2016-05-01", "2016-05-11", "2016-05-10")
+
+	c, err := r.Cadence()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, c.Intervals)
+	assert.Equal(t, 4*24*time.Hour, c.Mean)
+	assert.Equal(t, 2*24*time.Hour, c.Median)
+	assert.Equal(t, "2016-05-01", c.First.Format("2006-01-02"))
+	assert.Equal(t, "2016-05-13", c.Last.Format("2006-01-02"))
+
+	// test (successful) [even]
+	r = newTestDatedReleases("2016-05-13", "2016-05-01", "2016-05-11", "2016-05-10", "2016-05-09")
+
+	c, err = r.Cadence()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, c.Intervals)
+	assert.Equal(t, 3*24*time.Hour, c.Mean)
+	assert.Equal(t, 36*time.Hour, c.Median)
+
+	// test (error)
+	r = newTestDatedReleases("2016-05-13", "invalid")
+
+	c, err = r.Cadence()
+	assert.Nil(t, c)
+	assert.EqualError(t, err, "not enough dated releases")
+}
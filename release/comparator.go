@@ -0,0 +1,196 @@
+package release
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Comparator compares two releases. It returns -1 if the first release is
+// older than the second one, 1 if it's newer and 0 if both are equal.
+type Comparator func(a Releaser, b Releaser) int
+
+// SemanticComparator compares releases by their SemVer versions. Releases
+// without a version are always considered to be older than the versioned ones
+// and are compared only among themselves by their builds using the
+// CompareSparkleVersions. Keeping them as a separate group makes the
+// comparator transitive, so it's safe to use for sorting.
+//
+// This is the default comparator used by the Releases.SortByVersions.
+func SemanticComparator(a Releaser, b Releaser) int {
+	va, vb := a.Version(), b.Version()
+
+	switch {
+	case va != nil && vb != nil:
+		return va.Compare(vb)
+	case va == nil && vb != nil:
+		return -1
+	case va != nil && vb == nil:
+		return 1
+	}
+
+	return CompareSparkleVersions(a.Build(), b.Build())
+}
+
+// SparkleComparator compares releases the same way as the Sparkle Framework
+// does. The builds are compared when both releases have them, as they
+// represent the "CFBundleVersion". Otherwise, the original versions are
+// compared instead. See CompareSparkleVersions for the comparison rules.
+func SparkleComparator(a Releaser, b Releaser) int {
+	if a.Build() != "" && b.Build() != "" {
+		return CompareSparkleVersions(a.Build(), b.Build())
+	}
+
+	return CompareSparkleVersions(originalVersionOrBuild(a), originalVersionOrBuild(b))
+}
+
+// originalVersionOrBuild retrieves the original release version string if it's
+// available. Otherwise, returns the release build string.
+func originalVersionOrBuild(r Releaser) string {
+	if r.Version() != nil {
+		return r.Version().Original()
+	}
+
+	return r.Build()
+}
+
+// sparkleCharType holds the character types used by the
+// CompareSparkleVersions.
+type sparkleCharType int
+
+const (
+	sparkleNumber sparkleCharType = iota
+	sparklePeriod
+	sparkleString
+	sparkleSeparator
+)
+
+// sparkleTypeOf returns the sparkleCharType of the provided rune.
+func sparkleTypeOf(r rune) sparkleCharType {
+	switch {
+	case r == '.':
+		return sparklePeriod
+	case unicode.IsDigit(r):
+		return sparkleNumber
+	case unicode.IsSpace(r) || unicode.IsPunct(r):
+		return sparkleSeparator
+	}
+
+	return sparkleString
+}
+
+// sparkleParts splits the provided version string into the numeric, string
+// and period parts. Each period becomes a separate part while the separators
+// (whitespaces and punctuation) are dropped.
+func sparkleParts(s string) (parts []string, types []sparkleCharType) {
+	var current []rune
+	var currentType sparkleCharType
+
+	flush := func() {
+		if len(current) > 0 && currentType != sparkleSeparator {
+			parts = append(parts, string(current))
+			types = append(types, currentType)
+		}
+		current = nil
+	}
+
+	for _, r := range s {
+		t := sparkleTypeOf(r)
+		if len(current) > 0 && (t != currentType || t == sparklePeriod) {
+			flush()
+		}
+
+		current = append(current, r)
+		currentType = t
+	}
+
+	flush()
+
+	return parts, types
+}
+
+// compareNumbers compares two digit-only strings by their numeric values
+// without converting them, so the long build numbers can't overflow.
+func compareNumbers(a string, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// CompareSparkleVersions compares two version strings mirroring the
+// "SUStandardVersionComparator" from the Sparkle Framework. It returns -1 if a
+// is older than b, 1 if it's newer and 0 if both are equal.
+//
+// Both versions are split into the numeric, string and period parts which are
+// compared pairwise: numbers by their values and strings lexically. A string
+// part is always older than a number or a period, so "1.0b3" < "1.0.1", and a
+// number is newer than a period. When all common parts are equal, the longer
+// version is newer unless its next part is a string: "1.0" < "1.0.1", but
+// "1.0b3" < "1.0".
+func CompareSparkleVersions(a string, b string) int {
+	partsA, typesA := sparkleParts(a)
+	partsB, typesB := sparkleParts(b)
+
+	n := len(partsA)
+	if len(partsB) < n {
+		n = len(partsB)
+	}
+
+	for i := 0; i < n; i++ {
+		ta, tb := typesA[i], typesB[i]
+
+		if ta == tb {
+			var result int
+
+			switch ta {
+			case sparkleNumber:
+				result = compareNumbers(partsA[i], partsB[i])
+			case sparkleString:
+				result = strings.Compare(partsA[i], partsB[i])
+			}
+
+			if result != 0 {
+				return result
+			}
+
+			continue
+		}
+
+		if ta == sparkleString {
+			return -1
+		}
+
+		if tb == sparkleString {
+			return 1
+		}
+
+		if ta == sparkleNumber {
+			return 1
+		}
+
+		return -1
+	}
+
+	if len(partsA) > len(partsB) {
+		if typesA[n] == sparkleString {
+			return -1
+		}
+		return 1
+	}
+
+	if len(partsB) > len(partsA) {
+		if typesB[n] == sparkleString {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package release
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemanticComparator(t *testing.T) {
+	// preparations
+	r1, _ := New("1.0.0", "100")
+	r2, _ := New("1.1.0", "90")
+	r3, _ := NewLenient("invalid", "110")
+	r4, _ := NewLenient("invalid", "")
+
+	// test
+	assert.Equal(t, -1, SemanticComparator(r1, r2))
+	assert.Equal(t, 1, SemanticComparator(r2, r1))
+	assert.Equal(t, 0, SemanticComparator(r1, r1))
+	assert.Equal(t, -1, SemanticComparator(r3, r1))
+	assert.Equal(t, -1, SemanticComparator(r3, r2))
+	assert.Equal(t, -1, SemanticComparator(r4, r1))
+	assert.Equal(t, 1, SemanticComparator(r1, r4))
+	assert.Equal(t, 1, SemanticComparator(r3, r4))
+	assert.Equal(t, 0, SemanticComparator(r4, r4))
+
+	// test (transitivity) [unversioned release between the versioned ones]
+	a, _ := New("1.0", "100")
+	b, _ := NewLenient("invalid", "50")
+	c, _ := New("2.0", "10")
+
+	assert.Equal(t, -1, SemanticComparator(a, c))
+	assert.Equal(t, -1, SemanticComparator(b, c))
+	assert.Equal(t, -1, SemanticComparator(b, a))
+
+	releases := NewReleases([]Releaser{c, a, b})
+	releases.SortByVersions(ASC)
+	assert.Equal(t, []Releaser{b, a, c}, releases.Filtered())
+}
+
+func TestSparkleComparator(t *testing.T) {
+	// preparations
+	r1, _ := New("1.0.0", "100")
+	r2, _ := New("1.1.0", "90")
+	r3, _ := New("1.0.0", "")
+	r4, _ := NewLenient("invalid", "1.0.0.1")
+
+	// test (builds)
+	assert.Equal(t, 1, SparkleComparator(r1, r2))
+	assert.Equal(t, -1, SparkleComparator(r2, r1))
+
+	// test (versions)
+	assert.Equal(t, -1, SparkleComparator(r3, r2))
+	assert.Equal(t, 0, SparkleComparator(r3, r1))
+	assert.Equal(t, 1, SparkleComparator(r4, r3))
+}
+
+func TestCompareSparkleVersions(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.0", "1.0.1", -1},
+		{"1.5.10.4", "1.5.9", 1},
+		{"1.5.10.4", "1.5.10", 1},
+		{"1.0b3", "1.0", -1},
+		{"1.0b3", "1.0b10", -1},
+		{"1.0a", "1.0b", -1},
+		{"1.0b3", "1.0.1", -1},
+		{"2024.1b3", "2024.1", -1},
+		{"2024.1b3", "2023.12", 1},
+		{"1000", "999", 1},
+		{"1.0 (100)", "1.0 (99)", 1},
+		{"1.01", "1.1", 0},
+		{"123456789012345678901234567890", "123456789012345678901234567891", -1},
+		{"", "1.0", -1},
+	}
+
+	for _, testCase := range testCases {
+		msg := fmt.Sprintf("%s <=> %s", testCase.a, testCase.b)
+		assert.Equal(t, testCase.expected, CompareSparkleVersions(testCase.a, testCase.b), msg)
+		assert.Equal(t, -testCase.expected, CompareSparkleVersions(testCase.b, testCase.a), msg)
+	}
+}
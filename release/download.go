@@ -10,8 +10,16 @@ type Downloader interface {
 	SetLength(length int)
 	DsaSignature() string
 	SetDsaSignature(dsaSignature string)
+	EdSignature() string
+	SetEdSignature(edSignature string)
+	HasSignature() bool
 	Md5() string
 	SetMd5(dsaSignature string)
+	Sha256() string
+	SetSha256(sha256 string)
+	ExtraInfo() string
+	SetExtraInfo(extraInfo string)
+	Platform() Platform
 }
 
 // Download holds a single release download data.
@@ -28,14 +36,26 @@ type Download struct {
 	// dsaSignature specifies a file DSA signature value.
 	dsaSignature string
 
+	// edSignature specifies a file EdDSA (ed25519) signature value used by the
+	// Sparkle 2.
+	edSignature string
+
 	// md5 specifies a file MD5 checksum.
 	md5 string
+
+	// sha256 specifies a file SHA256 checksum.
+	sha256 string
+
+	// extraInfo specifies an additional file information provided by some
+	// providers. For example, the SourceForge "extra-info" holding the "file"
+	// command output. It's used to infer the download Platform.
+	extraInfo string
 }
 
 // NewDownload returns a new Download instance pointer. Requires an url to be
 // passed as a parameter. Optionally, the filetype can be passed as a second
-// parameter, the length as a third one, the dsaSignature as a fourth and the
-// md5 as a fifth.
+// parameter, the length as a third one, the dsaSignature as a fourth, the md5
+// as a fifth and the sha256 as a sixth.
 func NewDownload(url string, a ...interface{}) *Download {
 	d := &Download{
 		url: url,
@@ -57,6 +77,10 @@ func NewDownload(url string, a ...interface{}) *Download {
 		d.md5 = a[3].(string)
 	}
 
+	if len(a) > 4 {
+		d.sha256 = a[4].(string)
+	}
+
 	return d
 }
 
@@ -100,6 +124,22 @@ func (d *Download) SetDsaSignature(dsaSignature string) {
 	d.dsaSignature = dsaSignature
 }
 
+// EdSignature is a Download.edSignature getter.
+func (d *Download) EdSignature() string {
+	return d.edSignature
+}
+
+// SetEdSignature is a Download.edSignature setter.
+func (d *Download) SetEdSignature(edSignature string) {
+	d.edSignature = edSignature
+}
+
+// HasSignature checks whether the Download has either the DSA or the EdDSA
+// signature.
+func (d *Download) HasSignature() bool {
+	return d.dsaSignature != "" || d.edSignature != ""
+}
+
 // Md5 is a Download.md5 getter.
 func (d *Download) Md5() string {
 	return d.md5
@@ -109,3 +149,23 @@ func (d *Download) Md5() string {
 func (d *Download) SetMd5(md5 string) {
 	d.md5 = md5
 }
+
+// Sha256 is a Download.sha256 getter.
+func (d *Download) Sha256() string {
+	return d.sha256
+}
+
+// SetSha256 is a Download.sha256 setter.
+func (d *Download) SetSha256(sha256 string) {
+	d.sha256 = sha256
+}
+
+// ExtraInfo is a Download.extraInfo getter.
+func (d *Download) ExtraInfo() string {
+	return d.extraInfo
+}
+
+// SetExtraInfo is a Download.extraInfo setter.
+func (d *Download) SetExtraInfo(extraInfo string) {
+	d.extraInfo = extraInfo
+}
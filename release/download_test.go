@@ -15,6 +15,7 @@ func newTestDownload() *Download {
 		length:       100000,
 		dsaSignature: "MC4CFQCeqQ/MxlFt2H3rQfCPimChDPibCgIVAJhZmHcU8ZHylc7EjvbkVr3ardLp",
 		md5:          "098f6bcd4621d373cade4e832627b4f6",
+		sha256:       "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
 	}
 }
 
@@ -25,6 +26,7 @@ func TestNewDownload(t *testing.T) {
 		100000,
 		"MC4CFQCeqQ/MxlFt2H3rQfCPimChDPibCgIVAJhZmHcU8ZHylc7EjvbkVr3ardLp",
 		"098f6bcd4621d373cade4e832627b4f6",
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
 	)
 
 	assert.IsType(t, Download{}, *d)
@@ -33,6 +35,7 @@ func TestNewDownload(t *testing.T) {
 	assert.Equal(t, 100000, d.length)
 	assert.Equal(t, "MC4CFQCeqQ/MxlFt2H3rQfCPimChDPibCgIVAJhZmHcU8ZHylc7EjvbkVr3ardLp", d.dsaSignature)
 	assert.Equal(t, "098f6bcd4621d373cade4e832627b4f6", d.md5)
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", d.sha256)
 }
 
 func TestDownload_Url(t *testing.T) {
@@ -79,6 +82,28 @@ func TestDownload_SetDsaSignature(t *testing.T) {
 	assert.Equal(t, "test", d.dsaSignature)
 }
 
+func TestDownload_EdSignature(t *testing.T) {
+	d := newTestDownload()
+	assert.Equal(t, d.edSignature, d.EdSignature())
+}
+
+func TestDownload_SetEdSignature(t *testing.T) {
+	d := newTestDownload()
+	d.SetEdSignature("test")
+	assert.Equal(t, "test", d.edSignature)
+}
+
+func TestDownload_HasSignature(t *testing.T) {
+	d := NewDownload("https://example.com/app.dmg")
+	assert.False(t, d.HasSignature())
+
+	d.SetEdSignature("test")
+	assert.True(t, d.HasSignature())
+
+	d = NewDownload("https://example.com/app.dmg", "", 0, "test")
+	assert.True(t, d.HasSignature())
+}
+
 func TestDownload_Md5(t *testing.T) {
 	d := newTestDownload()
 	assert.Equal(t, d.md5, d.Md5())
@@ -89,3 +114,25 @@ func TestDownload_SetMd5(t *testing.T) {
 	d.SetMd5("test")
 	assert.Equal(t, "test", d.md5)
 }
+
+func TestDownload_Sha256(t *testing.T) {
+	d := newTestDownload()
+	assert.Equal(t, d.sha256, d.Sha256())
+}
+
+func TestDownload_SetSha256(t *testing.T) {
+	d := newTestDownload()
+	d.SetSha256("test")
+	assert.Equal(t, "test", d.sha256)
+}
+
+func TestDownload_ExtraInfo(t *testing.T) {
+	d := newTestDownload()
+	assert.Equal(t, d.extraInfo, d.ExtraInfo())
+}
+
+func TestDownload_SetExtraInfo(t *testing.T) {
+	d := newTestDownload()
+	d.SetExtraInfo("test")
+	assert.Equal(t, "test", d.extraInfo)
+}
@@ -0,0 +1,334 @@
+package release
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// OS holds different supported operating systems of the downloads.
+type OS int
+
+const (
+	// UnknownOS represents an unknown operating system. When used as a filter,
+	// any operating system matches.
+	UnknownOS OS = iota
+
+	// MacOS represents the macOS operating system.
+	MacOS
+
+	// Windows represents the Windows operating system.
+	Windows
+
+	// Linux represents the Linux operating system.
+	Linux
+)
+
+var osNames = [...]string{
+	"Unknown",
+	"macOS",
+	"Windows",
+	"Linux",
+}
+
+// String returns the string representation of the OS.
+func (o OS) String() string {
+	return osNames[o]
+}
+
+// Arch holds different supported architectures of the downloads.
+type Arch int
+
+const (
+	// UnknownArch represents an unknown architecture. When used as a filter,
+	// any architecture matches.
+	UnknownArch Arch = iota
+
+	// X86_64 represents the 64-bit x86 architecture.
+	X86_64
+
+	// ARM64 represents the 64-bit ARM architecture.
+	ARM64
+
+	// Universal represents the download supporting multiple architectures.
+	Universal
+
+	// I386 represents the 32-bit x86 architecture.
+	I386
+)
+
+var archNames = [...]string{
+	"Unknown",
+	"x86_64",
+	"arm64",
+	"universal",
+	"i386",
+}
+
+// String returns the string representation of the Arch.
+func (a Arch) String() string {
+	return archNames[a]
+}
+
+// Kind holds different supported package kinds of the downloads.
+type Kind int
+
+const (
+	// UnknownKind represents an unknown package kind.
+	UnknownKind Kind = iota
+
+	// DMG represents the macOS disk image.
+	DMG
+
+	// PKG represents the macOS installer package.
+	PKG
+
+	// ZIP represents the ZIP archive.
+	ZIP
+
+	// EXE represents the Windows executable.
+	EXE
+
+	// MSI represents the Windows Installer package.
+	MSI
+
+	// AppImage represents the Linux AppImage.
+	AppImage
+
+	// DEB represents the Debian package.
+	DEB
+
+	// RPM represents the RPM package.
+	RPM
+
+	// TarGz represents the gzipped tarball.
+	TarGz
+)
+
+var kindNames = [...]string{
+	"Unknown",
+	"dmg",
+	"pkg",
+	"zip",
+	"exe",
+	"msi",
+	"AppImage",
+	"deb",
+	"rpm",
+	"tar.gz",
+}
+
+// String returns the string representation of the Kind.
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// Platform represents the platform a download is intended for.
+type Platform struct {
+	OS   OS
+	Arch Arch
+	Kind Kind
+}
+
+// kindExtensions holds the filename extensions for each Kind.
+var kindExtensions = []struct {
+	kind       Kind
+	extensions []string
+}{
+	{TarGz, []string{".tar.gz", ".tgz"}},
+	{DMG, []string{".dmg"}},
+	{PKG, []string{".pkg", ".mpkg"}},
+	{ZIP, []string{".zip"}},
+	{EXE, []string{".exe"}},
+	{MSI, []string{".msi"}},
+	{AppImage, []string{".appimage"}},
+	{DEB, []string{".deb"}},
+	{RPM, []string{".rpm"}},
+}
+
+// kindMediaTypes holds the MIME types for each Kind.
+var kindMediaTypes = map[string]Kind{
+	"application/x-apple-diskimage":                 DMG,
+	"application/x-newton-compatible-pkg":           PKG,
+	"application/zip":                               ZIP,
+	"application/x-zip-compressed":                  ZIP,
+	"application/x-msdownload":                      EXE,
+	"application/vnd.microsoft.portable-executable": EXE,
+	"application/x-msi":                             MSI,
+	"application/x-ms-installer":                    MSI,
+	"application/vnd.appimage":                      AppImage,
+	"application/x-iso9660-appimage":                AppImage,
+	"application/vnd.debian.binary-package":         DEB,
+	"application/x-debian-package":                  DEB,
+	"application/x-rpm":                             RPM,
+	"application/x-redhat-package-manager":          RPM,
+	"application/x-gtar":                            TarGz,
+	"application/x-compressed-tar":                  TarGz,
+}
+
+// kindOS holds the operating systems implied by each Kind.
+var kindOS = map[Kind]OS{
+	DMG:      MacOS,
+	PKG:      MacOS,
+	EXE:      Windows,
+	MSI:      Windows,
+	AppImage: Linux,
+	DEB:      Linux,
+	RPM:      Linux,
+}
+
+// osPattern holds a RegExp pattern used to infer the OS.
+type osPattern struct {
+	os OS
+	re *regexp.Regexp
+}
+
+// osFilenamePatterns holds the patterns used to infer the OS from the
+// filename and osExtraInfoPatterns from the SourceForge "extra-info" which
+// holds the "file" command output. The first match wins.
+var (
+	osFilenamePatterns = []osPattern{
+		{MacOS, regexp.MustCompile(`(?i)(^|[^a-z])(macos|mac|osx|darwin)([^a-z]|$)`)},
+		{Windows, regexp.MustCompile(`(?i)(^|[^a-z])(windows|win|win32|win64)([^a-z]|$)`)},
+		{Linux, regexp.MustCompile(`(?i)(^|[^a-z])linux([^a-z]|$)`)},
+	}
+
+	osExtraInfoPatterns = []osPattern{
+		{MacOS, regexp.MustCompile(`Mach-O`)},
+		{Windows, regexp.MustCompile(`PE32|MS Windows|MS-DOS`)},
+		{Linux, regexp.MustCompile(`\bELF\b`)},
+	}
+)
+
+// archPatterns holds the patterns used to infer the Arch from both the
+// filename and the SourceForge "extra-info". The first match wins.
+var archPatterns = []struct {
+	arch Arch
+	re   *regexp.Regexp
+}{
+	{Universal, regexp.MustCompile(`(?i)universal`)},
+	{ARM64, regexp.MustCompile(`(?i)arm64|aarch64|apple[-_ ]?silicon`)},
+	{X86_64, regexp.MustCompile(`(?i)x86[-_]64|x64|amd64|win64`)},
+	{I386, regexp.MustCompile(`(?i)i[3-6]86|x86|win32|80386`)},
+}
+
+// filename returns the download filename extracted from the Download.url. The
+// trailing "/download" path segment used by SourceForge is skipped.
+func (d *Download) filename() string {
+	p := d.url
+	if u, err := url.Parse(d.url); err == nil {
+		p = u.Path
+	}
+
+	p = strings.TrimSuffix(strings.TrimSuffix(p, "/"), "/download")
+
+	return path.Base(p)
+}
+
+// Platform infers the Platform of the Download from its filename, MIME type
+// and the SourceForge "extra-info". Unrecognized values remain unknown.
+func (d *Download) Platform() Platform {
+	var p Platform
+
+	filename := d.filename()
+	lower := strings.ToLower(filename)
+
+	// kind
+	for _, k := range kindExtensions {
+		for _, ext := range k.extensions {
+			if strings.HasSuffix(lower, ext) {
+				p.Kind = k.kind
+				break
+			}
+		}
+
+		if p.Kind != UnknownKind {
+			break
+		}
+	}
+
+	if p.Kind == UnknownKind {
+		p.Kind = kindMediaTypes[strings.ToLower(d.filetype)]
+	}
+
+	// operating system
+	if os, ok := kindOS[p.Kind]; ok {
+		p.OS = os
+	} else {
+		p.OS = matchOS(filename, osFilenamePatterns)
+		if p.OS == UnknownOS {
+			p.OS = matchOS(d.extraInfo, osExtraInfoPatterns)
+		}
+	}
+
+	// architecture
+	for _, value := range []string{filename, d.extraInfo} {
+		for _, a := range archPatterns {
+			if a.re.MatchString(value) {
+				p.Arch = a.arch
+				break
+			}
+		}
+
+		if p.Arch != UnknownArch {
+			break
+		}
+	}
+
+	return p
+}
+
+// matchOS returns the OS of the first pattern matching the provided value.
+func matchOS(value string, patterns []osPattern) OS {
+	for _, o := range patterns {
+		if o.re.MatchString(value) {
+			return o.os
+		}
+	}
+
+	return UnknownOS
+}
+
+// Supports checks whether the Platform is suitable for the provided OS and
+// Arch. The UnknownOS and UnknownArch match any value, so the downloads which
+// OS or architecture can't be inferred, like "App-1.2.zip", are suitable as
+// well.
+func (p Platform) Supports(os OS, arch Arch) bool {
+	return p.osScore(os) > 0 && p.archScore(arch) > 0
+}
+
+// score returns how well the Platform suits the provided OS and Arch. The OS
+// match always outweighs the architecture one.
+func (p Platform) score(os OS, arch Arch) int {
+	return p.osScore(os)*4 + p.archScore(arch)
+}
+
+// osScore returns how well the Platform.OS suits the provided OS: 2 for an
+// exact match, 1 for the unknown download OS and 0 for the incompatible one.
+func (p Platform) osScore(os OS) int {
+	switch {
+	case os == UnknownOS || p.OS == os:
+		return 2
+	case p.OS == UnknownOS:
+		return 1
+	}
+
+	return 0
+}
+
+// archScore returns how well the Platform.Arch suits the provided Arch: 3 for
+// an exact match, 2 for the universal download, 1 for the unknown download
+// architecture and 0 for the incompatible one.
+func (p Platform) archScore(arch Arch) int {
+	switch {
+	case arch == UnknownArch || p.Arch == arch:
+		return 3
+	case p.Arch == Universal:
+		return 2
+	case p.Arch == UnknownArch:
+		return 1
+	}
+
+	return 0
+}
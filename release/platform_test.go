@@ -0,0 +1,111 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOS_String(t *testing.T) {
+	assert.Equal(t, "Unknown", UnknownOS.String())
+	assert.Equal(t, "macOS", MacOS.String())
+	assert.Equal(t, "Windows", Windows.String())
+	assert.Equal(t, "Linux", Linux.String())
+}
+
+func TestArch_String(t *testing.T) {
+	assert.Equal(t, "Unknown", UnknownArch.String())
+	assert.Equal(t, "x86_64", X86_64.String())
+	assert.Equal(t, "arm64", ARM64.String())
+	assert.Equal(t, "universal", Universal.String())
+	assert.Equal(t, "i386", I386.String())
+}
+
+func TestKind_String(t *testing.T) {
+	assert.Equal(t, "Unknown", UnknownKind.String())
+	assert.Equal(t, "dmg", DMG.String())
+	assert.Equal(t, "AppImage", AppImage.String())
+	assert.Equal(t, "tar.gz", TarGz.String())
+}
+
+func TestDownload_Platform(t *testing.T) {
+	testCases := []struct {
+		url       string
+		filetype  string
+		extraInfo string
+		expected  Platform
+	}{
+		{"https://example.com/app_2.0.0.dmg", "application/octet-stream", "", Platform{MacOS, UnknownArch, DMG}},
+		{"https://example.com/app_2.0.0_universal.dmg", "", "", Platform{MacOS, Universal, DMG}},
+		{"https://example.com/app_2.0.0_arm64.pkg", "", "", Platform{MacOS, ARM64, PKG}},
+		{"https://example.com/App-2.0.0-mac.zip", "", "", Platform{MacOS, UnknownArch, ZIP}},
+		{"https://example.com/app_2.0.0_x64.exe", "", "", Platform{Windows, X86_64, EXE}},
+		{"https://example.com/app_2.0.0_win32.msi", "", "", Platform{Windows, I386, MSI}},
+		{"https://example.com/app-2.0.0-win64.zip", "", "", Platform{Windows, X86_64, ZIP}},
+		{"https://example.com/App-2.0.0-x86_64.AppImage", "", "", Platform{Linux, X86_64, AppImage}},
+		{"https://example.com/app_2.0.0_amd64.deb", "", "", Platform{Linux, X86_64, DEB}},
+		{"https://example.com/app-2.0.0.aarch64.rpm", "", "", Platform{Linux, ARM64, RPM}},
+		{"https://example.com/app-2.0.0-linux-i686.tar.gz", "", "", Platform{Linux, I386, TarGz}},
+		{"https://example.com/app-2.0.0-darwin.tgz", "", "", Platform{MacOS, UnknownArch, TarGz}},
+		{"https://example.com/download?id=1", "application/x-apple-diskimage", "", Platform{MacOS, UnknownArch, DMG}},
+		{"https://example.com/download?id=1", "application/x-msdownload", "", Platform{Windows, UnknownArch, EXE}},
+		{
+			"https://sourceforge.net/projects/example/files/app/2.0.0/app_2.0.0/download",
+			"application/octet-stream",
+			"ELF 64-bit LSB executable, x86-64, version 1 (SYSV)",
+			Platform{Linux, X86_64, UnknownKind},
+		},
+		{
+			"https://sourceforge.net/projects/example/files/app/2.0.0/app_2.0.0/download",
+			"application/octet-stream",
+			"Mach-O universal binary with 2 architectures",
+			Platform{MacOS, Universal, UnknownKind},
+		},
+		{
+			"https://sourceforge.net/projects/example/files/app/2.0.0/app_2.0.0.exe/download",
+			"application/octet-stream",
+			"PE32 executable (GUI) Intel 80386, for MS Windows",
+			Platform{Windows, I386, EXE},
+		},
+		{
+			"https://example.com/selfupdate_2.0.0.bin",
+			"",
+			"VAX COFF executable not stripped",
+			Platform{UnknownOS, UnknownArch, UnknownKind},
+		},
+	}
+
+	for _, testCase := range testCases {
+		d := NewDownload(testCase.url, testCase.filetype)
+		d.SetExtraInfo(testCase.extraInfo)
+		assert.Equal(t, testCase.expected, d.Platform(), testCase.url)
+	}
+}
+
+func TestPlatform_Supports(t *testing.T) {
+	// preparations
+	p := Platform{MacOS, Universal, DMG}
+
+	// test
+	assert.True(t, p.Supports(MacOS, ARM64))
+	assert.True(t, p.Supports(MacOS, UnknownArch))
+	assert.True(t, p.Supports(UnknownOS, X86_64))
+	assert.False(t, p.Supports(Windows, X86_64))
+
+	p = Platform{Windows, X86_64, EXE}
+	assert.True(t, p.Supports(Windows, X86_64))
+	assert.False(t, p.Supports(Windows, I386))
+
+	p = Platform{Linux, UnknownArch, DEB}
+	assert.True(t, p.Supports(Linux, ARM64))
+
+	p = Platform{UnknownOS, UnknownArch, ZIP}
+	assert.True(t, p.Supports(MacOS, ARM64))
+	assert.True(t, p.Supports(Windows, UnknownArch))
+}
+
+func TestPlatform_score(t *testing.T) {
+	assert.True(t, Platform{MacOS, UnknownArch, DMG}.score(MacOS, ARM64) > Platform{UnknownOS, ARM64, ZIP}.score(MacOS, ARM64))
+	assert.True(t, Platform{UnknownOS, ARM64, ZIP}.score(MacOS, ARM64) > Platform{UnknownOS, UnknownArch, ZIP}.score(MacOS, ARM64))
+	assert.Equal(t, 0, Platform{Windows, X86_64, EXE}.osScore(MacOS))
+}
@@ -11,6 +11,8 @@ var PublishedDateTimeFormats = []string{
 	time.RFC1123,
 	time.RFC3339,
 	"Monday, January 02, 2006 15:04:05 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
 }
 
 // PublishedDateTimer is the interface that wraps the PublishedDateTime methods.
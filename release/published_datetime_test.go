@@ -42,6 +42,8 @@ func TestPublishedDateTime_Parse(t *testing.T) {
 		// custom
 		"Thu, 25 May 2017 19:26:48 UT":              "2017-05-25 19:26:48 +0000 UTC",
 		"Monday, January 12th, 2010 23:30:00 GMT-5": "2010-01-12 23:30:00 +0000 UTC",
+		"2016-05-13T10:00:00.453":                   "2016-05-13 10:00:00.453 +0000 UTC",
+		"2016-05-13":                                "2016-05-13 00:00:00 +0000 UTC",
 	}
 
 	// test (successful)
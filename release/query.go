@@ -0,0 +1,169 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Predicate represents a single release condition used by the Releases.Query.
+// Predicates can be combined into trees using the And, Or and Not.
+type Predicate func(r Releaser) bool
+
+// And returns a Predicate matching the release only when all the provided
+// predicates match. An empty And matches every release.
+func And(predicates ...Predicate) Predicate {
+	return func(r Releaser) bool {
+		for _, p := range predicates {
+			if !p(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate matching the release when at least one of the
+// provided predicates matches. An empty Or matches no releases.
+func Or(predicates ...Predicate) Predicate {
+	return func(r Releaser) bool {
+		for _, p := range predicates {
+			if p(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate inverting the provided one.
+func Not(p Predicate) Predicate {
+	return func(r Releaser) bool {
+		return !p(r)
+	}
+}
+
+// TitleMatches returns a Predicate matching the release title with the
+// provided RegExp string.
+func TitleMatches(regexpStr string) Predicate {
+	re := regexp.MustCompile(regexpStr)
+
+	return func(r Releaser) bool {
+		return re.MatchString(r.Title())
+	}
+}
+
+// MediaTypeMatches returns a Predicate matching the release when at least one
+// of its downloads media type matches the provided RegExp string.
+func MediaTypeMatches(regexpStr string) Predicate {
+	re := regexp.MustCompile(regexpStr)
+
+	return func(r Releaser) bool {
+		for _, d := range r.Downloads() {
+			if re.MatchString(d.Filetype()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// UrlMatches returns a Predicate matching the release when at least one of
+// its downloads URL matches the provided RegExp string.
+func UrlMatches(regexpStr string) Predicate {
+	re := regexp.MustCompile(regexpStr)
+
+	return func(r Releaser) bool {
+		for _, d := range r.Downloads() {
+			if re.MatchString(d.Url()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// PlatformMatches returns a Predicate matching the release when at least one
+// of its downloads suits the provided OS and Arch. The UnknownOS and
+// UnknownArch match any value.
+func PlatformMatches(os OS, arch Arch) Predicate {
+	return func(r Releaser) bool {
+		return r.BestDownload(os, arch) != nil
+	}
+}
+
+// PreRelease returns a Predicate matching only the pre-releases.
+func PreRelease() Predicate {
+	return func(r Releaser) bool {
+		return r.IsPreRelease()
+	}
+}
+
+// Commented returns a Predicate matching only the releases that have been
+// commented out in the feed.
+func Commented() Predicate {
+	return func(r Releaser) bool {
+		return r.IsCommented()
+	}
+}
+
+// VersionMatches returns a Predicate matching the release version with the
+// provided version constraint string, for example: ">= 1.2, < 2.0". Releases
+// without a version never match. Returns an error, if the constraint is
+// malformed.
+func VersionMatches(constraint string) (Predicate, error) {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("malformed constraint: %s", constraint)
+	}
+
+	return func(r Releaser) bool {
+		return r.Version() != nil && c.Check(r.Version())
+	}, nil
+}
+
+// MinimumSystemVersionMatches returns a Predicate matching the release
+// minimum system version with the provided version constraint string. The
+// minimum system version is parsed using the ParseSystemVersion, so the leading
+// system name is ignored. Releases without a valid minimum system version never
+// match. Returns an error, if the constraint is malformed.
+func MinimumSystemVersionMatches(constraint string) (Predicate, error) {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("malformed constraint: %s", constraint)
+	}
+
+	return func(r Releaser) bool {
+		v, err := ParseSystemVersion(r.MinimumSystemVersion())
+		if err != nil {
+			return false
+		}
+		return c.Check(v)
+	}, nil
+}
+
+// PublishedBetween returns a Predicate matching the releases published between
+// the provided times (both inclusive). A zero time leaves the corresponding
+// side of the range unbounded. Releases without a parsed published datetime
+// never match.
+func PublishedBetween(from time.Time, to time.Time) Predicate {
+	return func(r Releaser) bool {
+		p := r.PublishedDateTime()
+		if p == nil || p.Time() == nil {
+			return false
+		}
+
+		t := *p.Time()
+		if !from.IsZero() && t.Before(from) {
+			return false
+		}
+
+		if !to.IsZero() && t.After(to) {
+			return false
+		}
+
+		return true
+	}
+}
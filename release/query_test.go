@@ -0,0 +1,129 @@
+package release
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// queryTestVersions returns the version strings of the provided Releases
+// filtered by the provided Predicate.
+func queryTestVersions(p Predicate) (result []string) {
+	for _, r := range newTestReleases().Filtered() {
+		if p(r) {
+			result = append(result, r.Version().String())
+		}
+	}
+
+	return result
+}
+
+func TestAnd(t *testing.T) {
+	assert.Len(t, queryTestVersions(And()), 4)
+	assert.Equal(t, []string{"1.0.1"}, queryTestVersions(And(TitleMatches("1.0"), UrlMatches("1.0.1"))))
+}
+
+func TestOr(t *testing.T) {
+	assert.Len(t, queryTestVersions(Or()), 0)
+	assert.Equal(t, []string{"2.0.0-beta", "1.0.0"}, queryTestVersions(Or(PreRelease(), TitleMatches("1.0.0$"))))
+}
+
+func TestNot(t *testing.T) {
+	assert.Equal(t, []string{"1.1.0", "1.0.1", "1.0.0"}, queryTestVersions(Not(PreRelease())))
+}
+
+func TestTitleMatches(t *testing.T) {
+	assert.Equal(t, []string{"1.1.0"}, queryTestVersions(TitleMatches("Release 1.1")))
+}
+
+func TestMediaTypeMatches(t *testing.T) {
+	assert.Len(t, queryTestVersions(MediaTypeMatches("octet-stream")), 4)
+	assert.Len(t, queryTestVersions(MediaTypeMatches("zip")), 0)
+}
+
+func TestUrlMatches(t *testing.T) {
+	assert.Equal(t, []string{"2.0.0-beta"}, queryTestVersions(UrlMatches("app_2")))
+}
+
+func TestPlatformMatches(t *testing.T) {
+	assert.Len(t, queryTestVersions(PlatformMatches(MacOS, X86_64)), 4)
+	assert.Len(t, queryTestVersions(PlatformMatches(Linux, UnknownArch)), 0)
+}
+
+func TestPreRelease(t *testing.T) {
+	assert.Equal(t, []string{"2.0.0-beta"}, queryTestVersions(PreRelease()))
+}
+
+func TestCommented(t *testing.T) {
+	// preparations
+	releases := newTestReleases().Filtered()
+	releases[1].SetIsCommented(true)
+
+	// test
+	p := Commented()
+	assert.False(t, p(releases[0]))
+	assert.True(t, p(releases[1]))
+}
+
+func TestVersionMatches(t *testing.T) {
+	// test (successful)
+	p, err := VersionMatches(">= 1.0.1, < 2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.1.0", "1.0.1"}, queryTestVersions(p))
+
+	// test (error)
+	p, err = VersionMatches("invalid")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "malformed constraint: invalid")
+}
+
+func TestMinimumSystemVersionMatches(t *testing.T) {
+	// test (successful)
+	p, err := MinimumSystemVersionMatches("<= 10.9")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.1.0", "1.0.1", "1.0.0"}, queryTestVersions(p))
+
+	// test (successful) [system name]
+	r, _ := New("1.0.0", "")
+	r.SetMinimumSystemVersion("macOS 10.13")
+
+	p, err = MinimumSystemVersionMatches(">= 10.13")
+	assert.Nil(t, err)
+	assert.True(t, p(r))
+
+	// test (error)
+	p, err = MinimumSystemVersionMatches("invalid")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "malformed constraint: invalid")
+}
+
+func TestPublishedBetween(t *testing.T) {
+	// preparations
+	var releases []Releaser
+	for _, day := range []int{13, 12, 11, 10} {
+		r, _ := New(fmt.Sprintf("1.0.%d", day), "")
+		pt := time.Date(2016, 5, day, 12, 0, 0, 0, time.UTC)
+		r.SetPublishedDateTime(NewPublishedDateTime(&pt))
+		releases = append(releases, r)
+	}
+
+	from := time.Date(2016, 5, 11, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 5, 12, 23, 59, 59, 0, time.UTC)
+
+	// test
+	testCases := map[int]Predicate{
+		2: PublishedBetween(from, to),
+		3: PublishedBetween(from, time.Time{}),
+		1: PublishedBetween(time.Time{}, from),
+	}
+
+	for expected, p := range testCases {
+		assert.Equal(t, expected, NewReleases(releases).Query(p).Len())
+	}
+
+	// test (without published datetime)
+	r, _ := New("1.0.0", "")
+	assert.False(t, PublishedBetween(from, to)(r))
+}
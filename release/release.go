@@ -30,11 +30,17 @@ type Releaser interface {
 	SetReleaseNotesLink(releaseNotesLink string)
 	MinimumSystemVersion() string
 	SetMinimumSystemVersion(minimumSystemVersion string)
+	MaximumSystemVersion() string
+	SetMaximumSystemVersion(maximumSystemVersion string)
+	SupportsSystemVersion(v *version.Version) bool
 	AddDownload(d Download)
 	Downloads() []Download
 	SetDownloads(downloads []Download)
+	BestDownload(os OS, arch Arch) *Download
 	IsPreRelease() bool
 	SetIsPreRelease(isPreRelease bool)
+	IsCommented() bool
+	SetIsCommented(isCommented bool)
 }
 
 // Release represents a single application release.
@@ -62,6 +68,10 @@ type Release struct {
 	// current app release.
 	minimumSystemVersion string
 
+	// maximumSystemVersion specifies the maximum system version supported by
+	// the current app release.
+	maximumSystemVersion string
+
 	// downloads specify a slice of Download structs which represents a list of
 	// all current release downloads.
 	downloads []Download
@@ -72,6 +82,11 @@ type Release struct {
 	// is false. If the release version, build or any other provider-specific
 	// value points that a release is unstable, the value should become true.
 	isPreRelease bool
+
+	// isCommented specifies whether a release has been commented out in the
+	// feed as a whole or partly. Such releases are usually hidden by the
+	// vendors, so they can be filtered out.
+	isCommented bool
 }
 
 // New returns a new Release instance pointer. Requires both version and build
@@ -96,6 +111,19 @@ func New(version string, build string) (*Release, error) {
 	return r, nil
 }
 
+// NewLenient returns a new Release instance pointer the same way as New does.
+// However, when the provided version can't be parsed, the release is still
+// returned without a version alongside with the parsing error. This allows to
+// keep such releases and sort them by builds.
+func NewLenient(version string, build string) (*Release, error) {
+	r := &Release{
+		isPreRelease: false,
+		build:        build,
+	}
+
+	return r, r.SetVersionString(version)
+}
+
 // VersionOrBuildString retrieves the release version string if it's available.
 // Otherwise, returns the release build string.
 func (r *Release) VersionOrBuildString() string {
@@ -190,6 +218,32 @@ func (r *Release) SetMinimumSystemVersion(minimumSystemVersion string) {
 	r.minimumSystemVersion = minimumSystemVersion
 }
 
+// MaximumSystemVersion is a Release.maximumSystemVersion getter.
+func (r *Release) MaximumSystemVersion() string {
+	return r.maximumSystemVersion
+}
+
+// SetMaximumSystemVersion is a Release.maximumSystemVersion setter.
+func (r *Release) SetMaximumSystemVersion(maximumSystemVersion string) {
+	r.maximumSystemVersion = maximumSystemVersion
+}
+
+// SupportsSystemVersion checks whether the release can be installed on the
+// provided system version. The Release.minimumSystemVersion and the
+// Release.maximumSystemVersion are both inclusive and the empty or malformed
+// ones are considered to be unbounded.
+func (r *Release) SupportsSystemVersion(v *version.Version) bool {
+	if min, err := ParseSystemVersion(r.minimumSystemVersion); err == nil && v.LessThan(min) {
+		return false
+	}
+
+	if max, err := ParseSystemVersion(r.maximumSystemVersion); err == nil && v.GreaterThan(max) {
+		return false
+	}
+
+	return true
+}
+
 // AddDownload appends the provided Download to the Release.downloads slice.
 func (r *Release) AddDownload(d Download) {
 	r.downloads = append(r.downloads, d)
@@ -205,6 +259,30 @@ func (r *Release) SetDownloads(downloads []Download) {
 	r.downloads = downloads
 }
 
+// BestDownload returns the Release.downloads item that suits the provided OS
+// and Arch the best. The exact OS match is preferred over the download with an
+// unknown OS. Then the exact architecture match is preferred over the
+// universal download which is preferred over the download with an unknown
+// architecture. Returns nil, if there is no suitable download.
+func (r *Release) BestDownload(os OS, arch Arch) *Download {
+	var result *Download
+	best := 0
+
+	for i := range r.downloads {
+		p := r.downloads[i].Platform()
+		if !p.Supports(os, arch) {
+			continue
+		}
+
+		if score := p.score(os, arch); score > best {
+			result = &r.downloads[i]
+			best = score
+		}
+	}
+
+	return result
+}
+
 // IsPreRelease is a Release.isPreRelease getter.
 func (r *Release) IsPreRelease() bool {
 	return r.isPreRelease
@@ -214,3 +292,13 @@ func (r *Release) IsPreRelease() bool {
 func (r *Release) SetIsPreRelease(isPreRelease bool) {
 	r.isPreRelease = isPreRelease
 }
+
+// IsCommented is a Release.isCommented getter.
+func (r *Release) IsCommented() bool {
+	return r.isCommented
+}
+
+// SetIsCommented is a Release.isCommented setter.
+func (r *Release) SetIsCommented(isCommented bool) {
+	r.isCommented = isCommented
+}
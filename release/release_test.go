@@ -1,6 +1,7 @@
 package release
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -49,6 +50,23 @@ func TestNew(t *testing.T) {
 	assert.Nil(t, r)
 }
 
+func TestNewLenient(t *testing.T) {
+	// test (successful)
+	r, err := NewLenient("1.0.0", "1000")
+	assert.Nil(t, err)
+	assert.IsType(t, Release{}, *r)
+	assert.Equal(t, "1.0.0", r.version.String())
+	assert.Equal(t, "1000", r.build)
+
+	// test (error)
+	r, err = NewLenient("invalid", "1000")
+	assert.EqualError(t, err, "malformed version: invalid")
+	assert.NotNil(t, r)
+	assert.Nil(t, r.version)
+	assert.Equal(t, "1000", r.build)
+	assert.Equal(t, "1000", r.VersionOrBuildString())
+}
+
 func TestRelease_VersionOrBuildString(t *testing.T) {
 	// preparations
 	v := "1.0.0"
@@ -170,6 +188,43 @@ func TestRelease_SetMinimumSystemVersion(t *testing.T) {
 	assert.Equal(t, "10.13.6", r.minimumSystemVersion)
 }
 
+func TestRelease_MaximumSystemVersion(t *testing.T) {
+	r := newTestRelease()
+	assert.Equal(t, r.maximumSystemVersion, r.MaximumSystemVersion())
+}
+
+func TestRelease_SetMaximumSystemVersion(t *testing.T) {
+	r := newTestRelease()
+	r.SetMaximumSystemVersion("10.15")
+	assert.Equal(t, "10.15", r.maximumSystemVersion)
+}
+
+func TestRelease_SupportsSystemVersion(t *testing.T) {
+	testCases := []struct {
+		minimum  string
+		maximum  string
+		system   string
+		expected bool
+	}{
+		{"", "", "10.13.6", true},
+		{"10.9", "", "10.13.6", true},
+		{"10.13.6", "", "10.13.6", true},
+		{"10.14", "", "10.13.6", false},
+		{"10.9", "10.13", "10.13.6", false},
+		{"10.9", "10.14", "10.13.6", true},
+		{"invalid", "invalid", "10.13.6", true},
+	}
+
+	for _, testCase := range testCases {
+		r := new(Release)
+		r.SetMinimumSystemVersion(testCase.minimum)
+		r.SetMaximumSystemVersion(testCase.maximum)
+
+		v, _ := ParseSystemVersion(testCase.system)
+		assert.Equal(t, testCase.expected, r.SupportsSystemVersion(v), fmt.Sprintf("%s-%s", testCase.minimum, testCase.maximum))
+	}
+}
+
 func TestRelease_AddDownload(t *testing.T) {
 	// preparations
 	r := newTestRelease()
@@ -198,6 +253,31 @@ func TestRelease_SetDownloads(t *testing.T) {
 	assert.Equal(t, d, r.downloads)
 }
 
+func TestRelease_BestDownload(t *testing.T) {
+	// preparations
+	r := new(Release)
+	r.AddDownload(*NewDownload("https://example.com/app_2.0.0.exe"))
+	r.AddDownload(*NewDownload("https://example.com/app_2.0.0.dmg"))
+	r.AddDownload(*NewDownload("https://example.com/app_2.0.0_universal.dmg"))
+	r.AddDownload(*NewDownload("https://example.com/app_2.0.0_arm64.dmg"))
+
+	// test
+	assert.Equal(t, "https://example.com/app_2.0.0_arm64.dmg", r.BestDownload(MacOS, ARM64).Url())
+	assert.Equal(t, "https://example.com/app_2.0.0_universal.dmg", r.BestDownload(MacOS, X86_64).Url())
+	assert.Equal(t, "https://example.com/app_2.0.0.dmg", r.BestDownload(MacOS, UnknownArch).Url())
+	assert.Equal(t, "https://example.com/app_2.0.0.exe", r.BestDownload(Windows, X86_64).Url())
+	assert.Nil(t, r.BestDownload(Linux, X86_64))
+
+	// test (unknown OS)
+	r = new(Release)
+	r.AddDownload(*NewDownload("https://example.com/App-1.2.zip"))
+
+	assert.Equal(t, "https://example.com/App-1.2.zip", r.BestDownload(MacOS, ARM64).Url())
+
+	r.AddDownload(*NewDownload("https://example.com/App-1.2.dmg"))
+	assert.Equal(t, "https://example.com/App-1.2.dmg", r.BestDownload(MacOS, ARM64).Url())
+}
+
 func TestRelease_IsPreRelease(t *testing.T) {
 	r := newTestRelease()
 	assert.Equal(t, r.isPreRelease, r.IsPreRelease())
@@ -208,3 +288,14 @@ func TestRelease_SetIsPreRelease(t *testing.T) {
 	r.SetIsPreRelease(true)
 	assert.Equal(t, true, r.isPreRelease)
 }
+
+func TestRelease_IsCommented(t *testing.T) {
+	r := newTestRelease()
+	assert.Equal(t, r.isCommented, r.IsCommented())
+}
+
+func TestRelease_SetIsCommented(t *testing.T) {
+	r := newTestRelease()
+	r.SetIsCommented(true)
+	assert.Equal(t, true, r.isCommented)
+}
@@ -1,19 +1,35 @@
 package release
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
+	"time"
+
+	"github.com/hashicorp/go-version"
 )
 
 // Releaseser is the interface that wraps the Releases methods.
 //
 // TODO: Find a better Releaseser interface name.
 type Releaseser interface {
-	SortByVersions(s Sort)
+	SortByVersions(s Sort, comparator ...Comparator)
 	FilterByTitle(regexpStr string, inversed ...interface{})
 	FilterByMediaType(regexpStr string, inversed ...interface{})
 	FilterByUrl(regexpStr string, inversed ...interface{})
 	FilterByPrerelease(inversed ...interface{})
+	FilterByCommented(inversed ...interface{})
+	FilterByVersionConstraint(constraint string, inversed ...interface{}) error
+	FilterByNewerThan(v string) error
+	FilterByLatestPerLine(l Line)
+	FilterByPlatform(os OS, arch Arch, inversed ...interface{})
+	FilterByPublishedBetween(from time.Time, to time.Time, policy ...DatePolicy)
+	FilterByPublishedWithin(d time.Duration, policy ...DatePolicy)
+	SortByPublishedDateTime(s Sort)
+	FirstPublishedAfter(t time.Time) Releaser
+	Cadence() (*Cadence, error)
+	LatestForSystemVersion(v string) (Releaser, error)
+	Query(p Predicate) Releaseser
 	ResetFilters()
 	Len() int
 	First() Releaser
@@ -47,6 +63,38 @@ const (
 	DESC
 )
 
+// DatePolicy holds different behaviours for the releases without a published
+// datetime (missing or failed to parse) when filtering by dates.
+type DatePolicy int
+
+const (
+	// ExcludeUndated represents the exclusion of the releases without a
+	// published datetime.
+	ExcludeUndated DatePolicy = iota
+
+	// IncludeUndated represents the inclusion of the releases without a
+	// published datetime.
+	IncludeUndated
+)
+
+// Now returns the current local time. It's used by the recency filters and can
+// be replaced for testing purposes.
+var Now = time.Now
+
+// Line holds the version segments used to group releases into the release
+// lines.
+type Line int
+
+const (
+	// MajorLine represents the release lines grouped by the major version
+	// (1.x, 2.x and etc.).
+	MajorLine Line = iota + 1
+
+	// MinorLine represents the release lines grouped by the major and minor
+	// versions (1.0.x, 1.1.x and etc.).
+	MinorLine
+)
+
 // NewReleases returns a new Releases instance pointer. Requires []Releaser
 // slice to be passed as a parameter which will be set to the Releases.filtered
 // and the Releases.original.
@@ -59,11 +107,19 @@ func NewReleases(releases []Releaser) *Releases {
 
 // SortByVersions sorts the Releases.filtered slice by versions. Can be
 // useful if the versions order is inconsistent.
-func (r *Releases) SortByVersions(s Sort) {
+//
+// By default, the SemanticComparator is used. Another Comparator (for example,
+// the SparkleComparator) can be passed to change the comparison strategy.
+func (r *Releases) SortByVersions(s Sort, comparator ...Comparator) {
+	var data sort.Interface = ByVersion(r.filtered)
+	if len(comparator) > 0 && comparator[0] != nil {
+		data = byComparator{releases: r.filtered, compare: comparator[0]}
+	}
+
 	if s == ASC {
-		sort.Sort(ByVersion(r.filtered))
+		sort.Sort(data)
 	} else if s == DESC {
-		sort.Sort(sort.Reverse(ByVersion(r.filtered)))
+		sort.Sort(sort.Reverse(data))
 	}
 }
 
@@ -193,6 +249,248 @@ func (r *Releases) FilterByPrerelease(inversed ...interface{}) {
 	}, inverse)
 }
 
+// FilterByCommented filters all Releases.filtered by matching only the
+// releases that have been commented out in the feed.
+//
+// When inversed bool is set to true, the unmatched releases will be used
+// instead. This is useful to hide the commented out releases.
+func (r *Releases) FilterByCommented(inversed ...interface{}) {
+	inverse := false
+	if len(inversed) > 0 {
+		inverse = inversed[0].(bool)
+	}
+
+	r.filterBy(func(r Releaser) bool {
+		return r.IsCommented()
+	}, inverse)
+}
+
+// FilterByVersionConstraint filters all Releases.filtered by matching the
+// release version with the provided version constraint string. Multiple
+// constraints are separated by commas, for example: ">= 1.2, < 2.0". Releases
+// without a version never match. Returns an error, if the constraint is
+// malformed.
+//
+// When inversed bool is set to true, the unmatched releases will be used
+// instead.
+func (r *Releases) FilterByVersionConstraint(constraint string, inversed ...interface{}) error {
+	inverse := false
+	if len(inversed) > 0 {
+		inverse = inversed[0].(bool)
+	}
+
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("malformed constraint: %s", constraint)
+	}
+
+	r.filterBy(func(r Releaser) bool {
+		if r.Version() != nil && c.Check(r.Version()) {
+			return true
+		}
+		return false
+	}, inverse)
+
+	return nil
+}
+
+// FilterByNewerThan filters all Releases.filtered by matching only the
+// releases with versions newer than the provided one. This is useful to find
+// the updates available for the already installed version. Releases without a
+// version never match. Returns an error, if the provided version is
+// malformed.
+func (r *Releases) FilterByNewerThan(v string) error {
+	installed, err := version.NewVersion(v)
+	if err != nil {
+		return fmt.Errorf("malformed version: %s", v)
+	}
+
+	r.filterBy(func(r Releaser) bool {
+		if r.Version() != nil && r.Version().GreaterThan(installed) {
+			return true
+		}
+		return false
+	}, false)
+
+	return nil
+}
+
+// FilterByLatestPerLine filters all Releases.filtered by matching only the
+// latest release of each release line. The lines are grouped by the provided
+// Line. The order of the Releases.filtered is preserved and releases without
+// a version are dropped.
+//
+// Pre-releases are considered as well, so use FilterByPrerelease with an
+// inversed flag beforehand to get only the stable ones.
+func (r *Releases) FilterByLatestPerLine(l Line) {
+	latest := make(map[string]*version.Version)
+
+	for _, release := range r.filtered {
+		v := release.Version()
+		if v == nil {
+			continue
+		}
+
+		key := lineKey(v, l)
+		if current, ok := latest[key]; !ok || v.GreaterThan(current) {
+			latest[key] = v
+		}
+	}
+
+	r.filterBy(func(r Releaser) bool {
+		v := r.Version()
+		if v != nil && latest[lineKey(v, l)] == v {
+			return true
+		}
+		return false
+	}, false)
+}
+
+// lineKey returns the release line key for the provided version, which
+// consists of its first version segments depending on the provided Line.
+func lineKey(v *version.Version, l Line) string {
+	segments := v.Segments()
+	if int(l) < len(segments) {
+		segments = segments[:l]
+	}
+
+	return fmt.Sprint(segments)
+}
+
+// FilterByPublishedBetween filters all Releases.filtered by matching only the
+// releases published between the provided times (both inclusive). A zero time
+// leaves the corresponding side of the range unbounded.
+//
+// By default, releases without a published datetime are excluded. Pass the
+// IncludeUndated DatePolicy to keep them.
+func (r *Releases) FilterByPublishedBetween(from time.Time, to time.Time, policy ...DatePolicy) {
+	p := ExcludeUndated
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+
+	between := PublishedBetween(from, to)
+
+	r.filterBy(func(r Releaser) bool {
+		if !isDated(r) {
+			return p == IncludeUndated
+		}
+		return between(r)
+	}, false)
+}
+
+// FilterByPublishedWithin filters all Releases.filtered by matching only the
+// releases published within the provided duration until Now. For example,
+// 90 * 24 * time.Hour matches the releases published in the last 90 days.
+//
+// By default, releases without a published datetime are excluded. Pass the
+// IncludeUndated DatePolicy to keep them.
+func (r *Releases) FilterByPublishedWithin(d time.Duration, policy ...DatePolicy) {
+	now := Now()
+	r.FilterByPublishedBetween(now.Add(-d), now, policy...)
+}
+
+// SortByPublishedDateTime sorts the Releases.filtered slice by the published
+// datetime. Releases without a published datetime are always placed last
+// keeping their relative order.
+func (r *Releases) SortByPublishedDateTime(s Sort) {
+	sort.SliceStable(r.filtered, func(i, j int) bool {
+		a, b := r.filtered[i], r.filtered[j]
+
+		if !isDated(a) || !isDated(b) {
+			return isDated(a) && !isDated(b)
+		}
+
+		if s == DESC {
+			return a.PublishedDateTime().Time().After(*b.PublishedDateTime().Time())
+		}
+
+		return a.PublishedDateTime().Time().Before(*b.PublishedDateTime().Time())
+	})
+}
+
+// FirstPublishedAfter returns the earliest release from the Releases.filtered
+// published after the provided time. Returns nil, if there is no such release.
+func (r *Releases) FirstPublishedAfter(t time.Time) Releaser {
+	var result Releaser
+
+	for _, release := range r.filtered {
+		if !isDated(release) || !release.PublishedDateTime().Time().After(t) {
+			continue
+		}
+
+		if result == nil || release.PublishedDateTime().Time().Before(*result.PublishedDateTime().Time()) {
+			result = release
+		}
+	}
+
+	return result
+}
+
+// LatestForSystemVersion returns the newest release from the
+// Releases.filtered that can be installed on the provided system version, for
+// example: "10.13.6". Both minimum and maximum system versions are honored.
+// Returns nil, if there is no such release, and an error, if the provided
+// system version is malformed.
+func (r *Releases) LatestForSystemVersion(v string) (Releaser, error) {
+	sv, err := ParseSystemVersion(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Releaser
+
+	for _, release := range r.filtered {
+		if !release.SupportsSystemVersion(sv) {
+			continue
+		}
+
+		if result == nil || SemanticComparator(release, result) > 0 {
+			result = release
+		}
+	}
+
+	return result, nil
+}
+
+// isDated checks whether the provided release has a published datetime.
+func isDated(r Releaser) bool {
+	p := r.PublishedDateTime()
+	return p != nil && p.Time() != nil
+}
+
+// Query returns a new Releases view holding only the Releases.filtered
+// matching the provided Predicate. Unlike the Filter* methods, the current
+// Releases are left untouched, so multiple queries can be built from the same
+// set concurrently.
+func (r *Releases) Query(p Predicate) Releaseser {
+	result := make([]Releaser, 0, len(r.filtered))
+
+	for _, release := range r.filtered {
+		if p(release) {
+			result = append(result, release)
+		}
+	}
+
+	return NewReleases(result)
+}
+
+// FilterByPlatform filters all Releases.filtered by matching only the releases
+// having at least one download suitable for the provided OS and Arch. The
+// UnknownOS and UnknownArch match any value. Use the Release.BestDownload to
+// pick the download afterwards.
+//
+// When inversed bool is set to true, the unmatched releases will be used
+// instead.
+func (r *Releases) FilterByPlatform(os OS, arch Arch, inversed ...interface{}) {
+	inverse := false
+	if len(inversed) > 0 {
+		inverse = inversed[0].(bool)
+	}
+
+	r.filterBy(PlatformMatches(os, arch), inverse)
+}
+
 // ResetFilters resets the Releases.filtered to their original state before
 // applying any filters.
 func (r *Releases) ResetFilters() {
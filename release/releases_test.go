@@ -1,6 +1,7 @@
 package release
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -108,6 +109,25 @@ func newTestReleases() *Releases {
 	}
 }
 
+// newTestDatedReleases creates a new Releases instance for testing purposes
+// holding a release for each provided date and returns its pointer. The empty
+// date represents a release without a published datetime.
+func newTestDatedReleases(dates ...string) *Releases {
+	var releases []Releaser
+
+	for i, date := range dates {
+		r, _ := New(fmt.Sprintf("1.0.%d", i), "")
+
+		p := NewPublishedDateTime()
+		p.Parse(date)
+		r.SetPublishedDateTime(p)
+
+		releases = append(releases, r)
+	}
+
+	return NewReleases(releases)
+}
+
 func TestNewReleases(t *testing.T) {
 	r := NewReleases(nil)
 	assert.IsType(t, Releases{}, *r)
@@ -126,6 +146,27 @@ func TestReleases_SortByVersions(t *testing.T) {
 	// test (DESC)
 	r.SortByVersions(DESC)
 	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+
+	// test (comparator)
+	builds := []string{"1.5.10.4", "2024.1b3", "1.5.9", "2024.1", "1.5.10"}
+	var releases []Releaser
+	for _, b := range builds {
+		rel, _ := NewLenient("invalid", b)
+		releases = append(releases, rel)
+	}
+
+	r = NewReleases(releases)
+	r.SortByVersions(DESC, SparkleComparator)
+
+	var result []string
+	for _, rel := range r.Filtered() {
+		result = append(result, rel.Build())
+	}
+	assert.Equal(t, []string{"2024.1", "2024.1b3", "1.5.10.4", "1.5.10", "1.5.9"}, result)
+
+	// test (default comparator) [releases without versions]
+	r.SortByVersions(ASC)
+	assert.Equal(t, "1.5.9", r.filtered[0].Build())
 }
 
 func TestReleases_FilterByTitle(t *testing.T) {
@@ -180,6 +221,250 @@ func TestReleases_FilterByPrerelease(t *testing.T) {
 	assert.Len(t, r.filtered, 3)
 }
 
+func TestReleases_FilterByCommented(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+	r.filtered[1].SetIsCommented(true)
+
+	// test
+	assert.Len(t, r.filtered, 4)
+	r.FilterByCommented()
+	assert.Len(t, r.filtered, 1)
+	assert.Equal(t, "1.1.0", r.filtered[0].Version().String())
+	r.ResetFilters()
+
+	assert.Len(t, r.filtered, 4)
+	r.FilterByCommented(true)
+	assert.Len(t, r.filtered, 3)
+}
+
+func TestReleases_FilterByVersionConstraint(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+
+	// test (successful)
+	assert.Len(t, r.filtered, 4)
+	err := r.FilterByVersionConstraint(">= 1.0.1, < 2.0")
+	assert.Nil(t, err)
+	assert.Len(t, r.filtered, 2)
+	assert.Equal(t, "1.1.0", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[1].Version().String())
+	r.ResetFilters()
+
+	// test (successful) [inversed]
+	err = r.FilterByVersionConstraint("~> 1.0.0", true)
+	assert.Nil(t, err)
+	assert.Len(t, r.filtered, 2)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	assert.Equal(t, "1.1.0", r.filtered[1].Version().String())
+	r.ResetFilters()
+
+	// test (error)
+	err = r.FilterByVersionConstraint("invalid")
+	assert.EqualError(t, err, "malformed constraint: invalid")
+	assert.Len(t, r.filtered, 4)
+}
+
+func TestReleases_FilterByNewerThan(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+
+	// test (successful)
+	err := r.FilterByNewerThan("1.0.1")
+	assert.Nil(t, err)
+	assert.Len(t, r.filtered, 2)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	assert.Equal(t, "1.1.0", r.filtered[1].Version().String())
+	r.ResetFilters()
+
+	// test (successful) [latest installed]
+	err = r.FilterByNewerThan("2.0.0")
+	assert.Nil(t, err)
+	assert.Len(t, r.filtered, 0)
+	r.ResetFilters()
+
+	// test (error)
+	err = r.FilterByNewerThan("invalid")
+	assert.EqualError(t, err, "malformed version: invalid")
+	assert.Len(t, r.filtered, 4)
+}
+
+func TestReleases_FilterByLatestPerLine(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+
+	// test (major)
+	r.FilterByLatestPerLine(MajorLine)
+	assert.Len(t, r.filtered, 2)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	assert.Equal(t, "1.1.0", r.filtered[1].Version().String())
+	r.ResetFilters()
+
+	// test (minor)
+	r.FilterByLatestPerLine(MinorLine)
+	assert.Len(t, r.filtered, 3)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	assert.Equal(t, "1.1.0", r.filtered[1].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[2].Version().String())
+	r.ResetFilters()
+
+	// test (stable only)
+	r.FilterByPrerelease(true)
+	r.FilterByLatestPerLine(MajorLine)
+	assert.Len(t, r.filtered, 1)
+	assert.Equal(t, "1.1.0", r.filtered[0].Version().String())
+}
+
+func TestReleases_FilterByPublishedBetween(t *testing.T) {
+	// preparations
+	r := newTestDatedReleases("2016-05-13", "invalid", "2016-05-11", "2016-05-10")
+	from := time.Date(2016, 5, 11, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 5, 12, 0, 0, 0, 0, time.UTC)
+
+	// test (exclude undated)
+	r.FilterByPublishedBetween(from, to)
+	assert.Len(t, r.filtered, 1)
+	assert.Equal(t, "1.0.2", r.filtered[0].Version().String())
+	r.ResetFilters()
+
+	// test (include undated)
+	r.FilterByPublishedBetween(from, time.Time{}, IncludeUndated)
+	assert.Len(t, r.filtered, 3)
+	assert.Equal(t, "1.0.0", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[1].Version().String())
+	assert.Equal(t, "1.0.2", r.filtered[2].Version().String())
+}
+
+func TestReleases_FilterByPublishedWithin(t *testing.T) {
+	// preparations
+	r := newTestDatedReleases("2016-05-13", "invalid", "2016-05-11", "2016-02-01")
+	Now = func() time.Time {
+		return time.Date(2016, 5, 14, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() { Now = time.Now }()
+
+	// test (exclude undated)
+	r.FilterByPublishedWithin(90 * 24 * time.Hour)
+	assert.Len(t, r.filtered, 2)
+	r.ResetFilters()
+
+	// test (include undated)
+	r.FilterByPublishedWithin(2*24*time.Hour, IncludeUndated)
+	assert.Len(t, r.filtered, 2)
+	assert.Equal(t, "1.0.0", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[1].Version().String())
+}
+
+func TestReleases_SortByPublishedDateTime(t *testing.T) {
+	// preparations
+	r := newTestDatedReleases("2016-05-11", "invalid", "2016-05-13", "2016-05-10")
+
+	// test (ASC)
+	r.SortByPublishedDateTime(ASC)
+	assert.Equal(t, "1.0.3", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.0", r.filtered[1].Version().String())
+	assert.Equal(t, "1.0.2", r.filtered[2].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[3].Version().String())
+
+	// test (DESC)
+	r.SortByPublishedDateTime(DESC)
+	assert.Equal(t, "1.0.2", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.0", r.filtered[1].Version().String())
+	assert.Equal(t, "1.0.3", r.filtered[2].Version().String())
+	assert.Equal(t, "1.0.1", r.filtered[3].Version().String())
+}
+
+func TestReleases_FirstPublishedAfter(t *testing.T) {
+	// preparations
+	r := newTestDatedReleases("2016-05-13", "invalid", "2016-05-11", "2016-05-10")
+
+	// test
+	first := r.FirstPublishedAfter(time.Date(2016, 5, 10, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, "1.0.2", first.Version().String())
+
+	first = r.FirstPublishedAfter(time.Date(2016, 5, 13, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, first)
+}
+
+func TestReleases_LatestForSystemVersion(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+	r.filtered[1].SetMaximumSystemVersion("10.12")
+
+	// test
+	testCases := map[string]string{
+		"10.8":          "",
+		"10.9":          "1.1.0",
+		"macOS 10.13.6": "2.0.0-beta",
+		"10.12.6":       "2.0.0-beta",
+	}
+
+	for system, expected := range testCases {
+		latest, err := r.LatestForSystemVersion(system)
+		assert.Nil(t, err)
+
+		if expected == "" {
+			assert.Nil(t, latest, system)
+		} else {
+			assert.Equal(t, expected, latest.Version().String(), system)
+		}
+	}
+
+	// test (minimum and maximum system versions)
+	r.filtered[0].SetMinimumSystemVersion("10.14")
+
+	latest, err := r.LatestForSystemVersion("10.13.6")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", latest.Version().String())
+
+	// test (error)
+	latest, err = r.LatestForSystemVersion("invalid")
+	assert.Nil(t, latest)
+	assert.EqualError(t, err, "malformed system version: invalid")
+}
+
+func TestReleases_FilterByPlatform(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+	r.filtered[0].AddDownload(*NewDownload("https://example.com/app_2.0.0_x64.exe"))
+
+	// test
+	r.FilterByPlatform(MacOS, ARM64)
+	assert.Len(t, r.filtered, 4)
+	r.ResetFilters()
+
+	r.FilterByPlatform(Windows, UnknownArch)
+	assert.Len(t, r.filtered, 1)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	r.ResetFilters()
+
+	r.FilterByPlatform(Windows, X86_64, true)
+	assert.Len(t, r.filtered, 3)
+}
+
+func TestReleases_Query(t *testing.T) {
+	// preparations
+	r := newTestReleases()
+	v, _ := VersionMatches("< 1.1.0")
+
+	// test
+	q := r.Query(Or(PreRelease(), And(TitleMatches("1.0"), Not(v))))
+	assert.IsType(t, &Releases{}, q)
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, "2.0.0-beta", q.Filtered()[0].Version().String())
+	assert.Equal(t, "1.1.0", q.Filtered()[1].Version().String())
+
+	q = r.Query(v)
+	assert.Equal(t, 2, q.Len())
+
+	// test (source is untouched)
+	q.SortByVersions(ASC)
+	assert.Equal(t, "1.0.0", q.First().Version().String())
+	assert.Len(t, r.filtered, 4)
+	assert.Equal(t, "2.0.0-beta", r.filtered[0].Version().String())
+	assert.Equal(t, "1.0.0", r.filtered[3].Version().String())
+}
+
 func TestReleases_Len(t *testing.T) {
 	r := newTestReleases()
 	assert.Equal(t, len(r.filtered), r.Len())
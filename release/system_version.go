@@ -0,0 +1,23 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-version"
+)
+
+// reSystemVersion matches the version part of a system version string.
+var reSystemVersion = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// ParseSystemVersion parses the provided system version string into the
+// comparable version. The leading system name (for example, "macOS 10.13.6")
+// is ignored. Returns an error, if the string doesn't contain a version.
+func ParseSystemVersion(value string) (*version.Version, error) {
+	match := reSystemVersion.FindString(value)
+	if match == "" {
+		return nil, fmt.Errorf("malformed system version: %s", value)
+	}
+
+	return version.NewVersion(match)
+}
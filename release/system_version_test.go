@@ -0,0 +1,28 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSystemVersion(t *testing.T) {
+	testCases := map[string]string{
+		"10.13.6":       "10.13.6",
+		"10.9":          "10.9.0",
+		"macOS 10.13.6": "10.13.6",
+		"11":            "11.0.0",
+	}
+
+	// test (successful)
+	for value, expected := range testCases {
+		v, err := ParseSystemVersion(value)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, v.String())
+	}
+
+	// test (error)
+	v, err := ParseSystemVersion("invalid")
+	assert.Nil(t, v)
+	assert.EqualError(t, err, "malformed system version: invalid")
+}
@@ -0,0 +1,67 @@
+package source
+
+import (
+	"io/fs"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// FSer is the interface that wraps the FS methods.
+type FSer interface {
+	appcaster.Sourcer
+	FS() fs.FS
+	Filepath() string
+	SetFilepath(filepath string)
+}
+
+// FS represents an appcast source from the file inside an fs.FS, for example,
+// the embedded fixtures.
+type FS struct {
+	*appcaster.Source
+	fsys     fs.FS
+	filepath string
+}
+
+// NewFS returns a new FS instance pointer with the FS.fsys and FS.filepath
+// set. The path should be valid according to the fs.ValidPath.
+func NewFS(fsys fs.FS, path string) *FS {
+	return &FS{
+		Source:   &appcaster.Source{},
+		fsys:     fsys,
+		filepath: path,
+	}
+}
+
+// Load loads an appcast content into the FS.Source.content from the file
+// inside the FS.fsys by using the path specified in FS.filepath set earlier.
+func (f *FS) Load() error {
+	data, err := fs.ReadFile(f.fsys, f.filepath)
+	if err != nil {
+		return err
+	}
+
+	f.SetContent(data)
+
+	if err := f.NormalizeContent(); err != nil {
+		return err
+	}
+
+	f.GenerateChecksum(appcaster.SHA256)
+
+	return nil
+}
+
+// FS is an FS.fsys getter.
+func (f *FS) FS() fs.FS {
+	return f.fsys
+}
+
+// Filepath is an FS.filepath getter.
+func (f *FS) Filepath() string {
+	return f.filepath
+}
+
+// SetFilepath is an FS.filepath setter.
+func (f *FS) SetFilepath(filepath string) {
+	f.filepath = filepath
+}
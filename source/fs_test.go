@@ -0,0 +1,60 @@
+package source
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestFS creates a new fstest.MapFS for testing purposes with the single
+// "appcast.xml" file containing []byte("test").
+func newTestFS() fstest.MapFS {
+	return fstest.MapFS{
+		"testdata/appcast.xml": &fstest.MapFile{Data: []byte("test")},
+	}
+}
+
+func TestNewFS(t *testing.T) {
+	// preparations
+	fsys := newTestFS()
+
+	// test
+	src := NewFS(fsys, "testdata/appcast.xml")
+	assert.IsType(t, FS{}, *src)
+	assert.NotNil(t, src.Source)
+	assert.Equal(t, fsys, src.fsys)
+	assert.Equal(t, "testdata/appcast.xml", src.filepath)
+}
+
+func TestFS_Load(t *testing.T) {
+	// test (successful)
+	src := NewFS(newTestFS(), "testdata/appcast.xml")
+	err := src.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, src.Provider())
+	assert.Equal(t, []byte("test"), src.Content())
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", src.Checksum().String())
+
+	// test (error)
+	src = NewFS(newTestFS(), "testdata/unknown.xml")
+	err = src.Load()
+	assert.Error(t, err)
+	assert.Nil(t, src.Content())
+}
+
+func TestFS_FS(t *testing.T) {
+	src := NewFS(newTestFS(), "testdata/appcast.xml")
+	assert.Equal(t, src.fsys, src.FS())
+}
+
+func TestFS_Filepath(t *testing.T) {
+	src := NewFS(newTestFS(), "testdata/appcast.xml")
+	assert.Equal(t, src.filepath, src.Filepath())
+}
+
+func TestFS_SetFilepath(t *testing.T) {
+	src := NewFS(newTestFS(), "testdata/appcast.xml")
+	src.SetFilepath("")
+	assert.Empty(t, src.filepath)
+}
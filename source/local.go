@@ -39,6 +39,11 @@ func (l *Local) Load() error {
 	}
 
 	l.SetContent(data)
+
+	if err := l.NormalizeContent(); err != nil {
+		return err
+	}
+
 	l.GenerateChecksum(appcaster.SHA256)
 
 	return nil
@@ -61,6 +61,17 @@ func TestLocal_Load(t *testing.T) {
 	assert.Nil(t, src.Provider())
 	assert.Equal(t, string(content), string(src.Content()))
 
+	// test (successful) [charset]
+	LocalReadFile = func(filename string) ([]byte, error) {
+		return []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><t>Caf\xE9</t>"), nil
+	}
+
+	src = NewLocal(path)
+	err = src.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><t>Café</t>", string(src.Content()))
+	assert.Equal(t, "ISO-8859-1", src.Charset())
+
 	// test (error)
 	LocalReadFile = func(filename string) ([]byte, error) {
 		return nil, fmt.Errorf("error")
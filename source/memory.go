@@ -0,0 +1,48 @@
+package source
+
+import (
+	"fmt"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Memory represents an appcast source from the in-memory content.
+type Memory struct {
+	*appcaster.Source
+	data []byte
+}
+
+// NewMemory returns a new Memory instance pointer with the Memory.data set.
+//
+// Supports both the []byte and string content as an argument.
+func NewMemory(content interface{}) (*Memory, error) {
+	var data []byte
+
+	switch v := content.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, fmt.Errorf("unsupported content type: %T", content)
+	}
+
+	return &Memory{
+		Source: &appcaster.Source{},
+		data:   data,
+	}, nil
+}
+
+// Load loads an appcast content into the Memory.Source.content from the
+// Memory.data set earlier.
+func (m *Memory) Load() error {
+	m.SetContent(m.data)
+
+	if err := m.NormalizeContent(); err != nil {
+		return err
+	}
+
+	m.GenerateChecksum(appcaster.SHA256)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemory(t *testing.T) {
+	// test ([]byte)
+	src, err := NewMemory([]byte("test"))
+	assert.Nil(t, err)
+	assert.IsType(t, Memory{}, *src)
+	assert.NotNil(t, src.Source)
+	assert.Equal(t, []byte("test"), src.data)
+
+	// test (string)
+	src, err = NewMemory("test")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("test"), src.data)
+
+	// test (error)
+	src, err = NewMemory(1)
+	assert.Nil(t, src)
+	assert.EqualError(t, err, "unsupported content type: int")
+}
+
+func TestMemory_Load(t *testing.T) {
+	// preparations
+	src, _ := NewMemory("test")
+
+	// test
+	err := src.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, src.Provider())
+	assert.Equal(t, []byte("test"), src.Content())
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", src.Checksum().String())
+}
@@ -0,0 +1,73 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// Stdin is the reader used by the sources created with NewStdin.
+var Stdin io.Reader = os.Stdin
+
+// Readerer is the interface that wraps the Reader methods.
+type Readerer interface {
+	appcaster.Sourcer
+	Reader() io.Reader
+	SetReader(reader io.Reader)
+}
+
+// Reader represents an appcast source from an arbitrary io.Reader.
+type Reader struct {
+	*appcaster.Source
+	reader io.Reader
+}
+
+// NewReader returns a new Reader instance pointer with the Reader.reader set.
+func NewReader(reader io.Reader) *Reader {
+	return &Reader{
+		Source: &appcaster.Source{},
+		reader: reader,
+	}
+}
+
+// NewStdin returns a new Reader instance pointer reading from the Stdin.
+func NewStdin() *Reader {
+	return NewReader(Stdin)
+}
+
+// Load loads an appcast content into the Reader.Source.content by reading the
+// Reader.reader until EOF. The reader is consumed, so the content can be
+// loaded only once unless a new reader is set.
+func (r *Reader) Load() error {
+	if r.reader == nil {
+		return fmt.Errorf("no reader")
+	}
+
+	data, err := ioutil.ReadAll(r.reader)
+	if err != nil {
+		return err
+	}
+
+	r.SetContent(data)
+
+	if err := r.NormalizeContent(); err != nil {
+		return err
+	}
+
+	r.GenerateChecksum(appcaster.SHA256)
+
+	return nil
+}
+
+// Reader is a Reader.reader getter.
+func (r *Reader) Reader() io.Reader {
+	return r.reader
+}
+
+// SetReader is a Reader.reader setter.
+func (r *Reader) SetReader(reader io.Reader) {
+	r.reader = reader
+}
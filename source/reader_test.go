@@ -0,0 +1,75 @@
+package source
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/appcaster"
+)
+
+// errorReader is an io.Reader that always fails.
+type errorReader struct{}
+
+// Read returns the "error" error.
+func (errorReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("error")
+}
+
+func TestNewReader(t *testing.T) {
+	// preparations
+	reader := strings.NewReader("test")
+
+	// test
+	src := NewReader(reader)
+	assert.IsType(t, Reader{}, *src)
+	assert.NotNil(t, src.Source)
+	assert.Equal(t, reader, src.reader)
+}
+
+func TestNewStdin(t *testing.T) {
+	// preparations
+	stdin := Stdin
+	Stdin = strings.NewReader("test")
+	defer func() { Stdin = stdin }()
+
+	// test
+	src := NewStdin()
+	assert.IsType(t, Reader{}, *src)
+	assert.Equal(t, Stdin, src.reader)
+}
+
+func TestReader_Load(t *testing.T) {
+	// test (successful)
+	src := NewReader(strings.NewReader("test"))
+	err := src.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, src.Provider())
+	assert.Equal(t, []byte("test"), src.Content())
+	assert.Equal(t, appcaster.SHA256, src.Checksum().Algorithm())
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", src.Checksum().String())
+
+	// test (error)
+	src = NewReader(errorReader{})
+	err = src.Load()
+	assert.EqualError(t, err, "error")
+	assert.Nil(t, src.Content())
+
+	// test (no reader)
+	src = NewReader(nil)
+	err = src.Load()
+	assert.EqualError(t, err, "no reader")
+}
+
+func TestReader_Reader(t *testing.T) {
+	src := NewReader(strings.NewReader("test"))
+	assert.Equal(t, src.reader, src.Reader())
+}
+
+func TestReader_SetReader(t *testing.T) {
+	src := NewReader(strings.NewReader("test"))
+	src.SetReader(nil)
+	assert.Nil(t, src.reader)
+}
@@ -66,6 +66,10 @@ func (r *Remote) Load() error {
 	body, _ := ioutil.ReadAll(resp.Body)
 	r.SetContent(body)
 
+	if err := r.NormalizeContent(resp.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+
 	r.GenerateChecksum(appcaster.SHA256)
 
 	return nil
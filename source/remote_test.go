@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
-	"gopkg.in/jarcoal/httpmock.v1"
 
 	"github.com/victorpopkov/go-appcast/appcaster"
 	"github.com/victorpopkov/go-appcast/client"
@@ -65,7 +65,7 @@ func TestNewRemote(t *testing.T) {
 	src, err = NewRemote(url)
 	assert.Nil(t, src)
 	assert.Error(t, err)
-	assert.EqualError(t, err, fmt.Sprintf("parse %s: invalid URL escape \"%%31\"", url))
+	assert.EqualError(t, err, fmt.Sprintf("parse %q: invalid URL escape \"%%31\"", url))
 }
 
 func TestRemote_Load(t *testing.T) {
@@ -86,6 +86,54 @@ func TestRemote_Load(t *testing.T) {
 	assert.Nil(t, src.Provider())
 	assert.Equal(t, content, src.Content())
 
+	// test (successful) [charset]
+	resp := httpmock.NewBytesResponse(200, []byte("<t>\xCF\xF0\xE8\xE2\xE5\xF2</t>"))
+	resp.Header.Set("Content-Type", "text/xml; charset=windows-1251")
+	httpmock.RegisterResponder("GET", url, httpmock.ResponderFromResponse(resp))
+
+	src, err = NewRemote(url)
+	assert.Nil(t, err)
+	err = src.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "<t>Привет</t>", string(src.Content()))
+	assert.Equal(t, "windows-1251", src.Charset())
+
+	// test (successful) [charset, valid utf-8]
+	resp = httpmock.NewBytesResponse(200, []byte("<t>Café</t>"))
+	resp.Header.Set("Content-Type", "text/xml; charset=ISO-8859-1")
+	httpmock.RegisterResponder("GET", url, httpmock.ResponderFromResponse(resp))
+
+	src, err = NewRemote(url)
+	assert.Nil(t, err)
+	err = src.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "<t>Café</t>", string(src.Content()))
+	assert.Equal(t, "utf-8", src.Charset())
+
+	// test (successful) [unsupported charset]
+	resp = httpmock.NewBytesResponse(200, content)
+	resp.Header.Set("Content-Type", "text/xml; charset=iso-8859-15")
+	httpmock.RegisterResponder("GET", url, httpmock.ResponderFromResponse(resp))
+
+	src, err = NewRemote(url)
+	assert.Nil(t, err)
+	err = src.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, content, src.Content())
+	assert.Equal(t, "utf-8", src.Charset())
+
+	// test (successful) [unsupported declared charset]
+	resp = httpmock.NewBytesResponse(200, []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-15\"?>"))
+	httpmock.RegisterResponder("GET", url, httpmock.ResponderFromResponse(resp))
+
+	src, err = NewRemote(url)
+	assert.Nil(t, err)
+	err = src.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-15\"?>"), src.Content())
+	assert.Equal(t, "utf-8", src.Charset())
+	assert.NotNil(t, src.Checksum())
+
 	// test (error)
 	src = newTestRemote()
 	src.request.HTTPRequest.URL = nil
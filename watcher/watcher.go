@@ -0,0 +1,469 @@
+// Package watcher periodically polls the remote appcasts and emits events
+// about the new and removed releases.
+//
+// Each poll re-fetches the appcast and compares its SHA256 checksum with the
+// previous one. Only when the checksum differs, the appcast is unmarshalled and
+// its releases are compared with the previous ones using the diff package.
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/victorpopkov/go-appcast"
+	"github.com/victorpopkov/go-appcast/client"
+	"github.com/victorpopkov/go-appcast/diff"
+	"github.com/victorpopkov/go-appcast/release"
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// DefaultInterval is the default interval between the polls of a single feed.
+var DefaultInterval = 10 * time.Minute
+
+// DefaultTimeout is the default time limit for fetching a single feed.
+var DefaultTimeout = 30 * time.Second
+
+// MaxQueuedEvents is the maximum number of events queued for a single channel.
+// When the queue is full, the oldest events are dropped.
+var MaxQueuedEvents = 1000
+
+// EventType holds different supported event types.
+type EventType int
+
+const (
+	// NewRelease represents a new stable release.
+	NewRelease EventType = iota
+
+	// NewPreRelease represents a new pre-release.
+	NewPreRelease
+
+	// ReleaseRemoved represents a release removed from the feed.
+	ReleaseRemoved
+
+	// FeedBroken represents a feed that can't be loaded or unmarshalled.
+	FeedBroken
+)
+
+var eventTypeNames = [...]string{
+	"new release",
+	"new pre-release",
+	"release removed",
+	"feed broken",
+}
+
+// String returns the string representation of the EventType.
+func (t EventType) String() string {
+	return eventTypeNames[t]
+}
+
+// Event represents a single feed change.
+type Event struct {
+	// Type specifies the event type.
+	Type EventType
+
+	// Url specifies the feed URL.
+	Url string
+
+	// Release specifies the added or removed release. It's nil for the
+	// FeedBroken event.
+	Release release.Releaser
+
+	// Err specifies the error that broke the feed. It's nil for all the
+	// events except the FeedBroken.
+	Err error
+
+	// Time specifies when the event has occurred.
+	Time time.Time
+}
+
+// Handler is the callback receiving the events.
+type Handler func(e Event)
+
+// feed represents a single watched feed state.
+type feed struct {
+	sync.Mutex
+
+	// url specifies the feed URL.
+	url string
+
+	// interval specifies the interval between the polls.
+	interval time.Duration
+
+	// checksum specifies the SHA256 checksum of the last successfully
+	// unmarshalled feed content.
+	checksum string
+
+	// releases specify the releases of the last successfully unmarshalled
+	// feed. It's nil until the first successful poll.
+	releases release.Releaseser
+
+	// broken specifies whether the last poll has failed.
+	broken bool
+}
+
+// notifier represents a single channel receiving the events. The events are
+// queued and sent by a separate goroutine, so the polling is never blocked by
+// the channel reader.
+type notifier struct {
+	sync.Mutex
+
+	// ch specifies the channel receiving the events.
+	ch chan<- Event
+
+	// queue specifies the events waiting to be sent. It holds at most
+	// MaxQueuedEvents events.
+	queue []Event
+
+	// dropped specifies the number of events that have never been sent.
+	dropped int
+
+	// sending specifies whether the sending goroutine is running.
+	sending bool
+}
+
+// send queues the provided events dropping the oldest ones when the queue is
+// full and starts the sending goroutine, if it's not running yet. The sending
+// goroutine exits when the provided done channel is closed.
+func (n *notifier) send(events []Event, done <-chan struct{}) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.queue = append(n.queue, events...)
+
+	if over := len(n.queue) - MaxQueuedEvents; over > 0 {
+		n.queue = n.queue[over:]
+		n.dropped += over
+	}
+
+	if !n.sending {
+		n.sending = true
+		go n.flush(done)
+	}
+}
+
+// flush sends the queued events in order until the queue is empty or the
+// provided done channel is closed. In the latter case, the events left are
+// dropped.
+func (n *notifier) flush(done <-chan struct{}) {
+	for {
+		n.Lock()
+		if len(n.queue) == 0 {
+			n.sending = false
+			n.Unlock()
+			return
+		}
+
+		e := n.queue[0]
+		n.queue = n.queue[1:]
+		n.Unlock()
+
+		select {
+		case n.ch <- e:
+		case <-done:
+			n.Lock()
+			n.dropped += len(n.queue) + 1
+			n.queue = nil
+			n.sending = false
+			n.Unlock()
+			return
+		}
+	}
+}
+
+// Watcher represents the appcasts poller.
+type Watcher struct {
+	mu        sync.Mutex
+	feeds     map[string]*feed
+	handlers  []Handler
+	notifiers []*notifier
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	running   bool
+}
+
+// New returns a new Watcher instance pointer.
+func New() *Watcher {
+	return &Watcher{
+		feeds: make(map[string]*feed),
+	}
+}
+
+// Add adds the feed URL to be watched. Optionally, the interval between the
+// polls can be passed as a parameter. Otherwise, the DefaultInterval is used.
+// Returns an error, if the feed is already watched or the watcher is running.
+func (w *Watcher) Add(url string, interval ...time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.running {
+		return errors.New("watcher is running")
+	}
+
+	if _, ok := w.feeds[url]; ok {
+		return fmt.Errorf("feed is already watched: %s", url)
+	}
+
+	f := &feed{url: url, interval: DefaultInterval}
+	if len(interval) > 0 && interval[0] > 0 {
+		f.interval = interval[0]
+	}
+
+	w.feeds[url] = f
+
+	return nil
+}
+
+// Subscribe adds the Handler which will be called for each event. Handlers
+// are called synchronously from the polling goroutines, so they should return
+// quickly.
+func (w *Watcher) Subscribe(h Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, h)
+}
+
+// Notify adds the channel which will receive each event. Sending never blocks
+// the polling: the events are queued and sent in order by a separate goroutine.
+// At most MaxQueuedEvents events are queued for the channel and the events
+// which haven't been received until the watcher is stopped are dropped. See
+// Watcher.Dropped.
+func (w *Watcher) Notify(ch chan<- Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.notifiers = append(w.notifiers, &notifier{ch: ch})
+}
+
+// Start starts polling each feed in its own goroutine. Each feed is polled
+// immediately and then once per its interval. The first successful poll only
+// remembers the releases without emitting any events.
+func (w *Watcher) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.running = true
+	w.cancel = cancel
+
+	for _, f := range w.feeds {
+		w.wg.Add(1)
+		go w.run(ctx, f)
+	}
+}
+
+// Stop stops polling and waits until all the polling goroutines exit. The
+// in-flight feed requests are cancelled.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+
+	w.cancel()
+	w.running = false
+	w.mu.Unlock()
+
+	w.wg.Wait()
+}
+
+// run polls the provided feed until the context is cancelled.
+func (w *Watcher) run(ctx context.Context, f *feed) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		events := w.poll(ctx, f)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.dispatch(ctx, events)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll polls the watched feed once and delivers the resulting events to the
+// subscribers. It's useful when the polling is scheduled externally. Returns
+// the delivered events and an error, if the feed isn't watched.
+func (w *Watcher) Poll(url string) ([]Event, error) {
+	w.mu.Lock()
+	f, ok := w.feeds[url]
+	w.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("feed is not watched: %s", url)
+	}
+
+	events := w.poll(context.Background(), f)
+	w.dispatch(context.Background(), events)
+
+	return events, nil
+}
+
+// poll fetches the provided feed within the DefaultTimeout and returns the
+// events comparing its releases with the previous ones. The request is
+// cancelled alongside with the provided context.
+func (w *Watcher) poll(ctx context.Context, f *feed) []Event {
+	f.Lock()
+	defer f.Unlock()
+
+	now := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	req, err := client.NewRequest(f.url)
+	if err != nil {
+		return f.fail(err, now)
+	}
+
+	req.HTTPRequest = req.HTTPRequest.WithContext(ctx)
+
+	src, err := source.NewRemote(req)
+	if err == nil {
+		err = src.Load()
+	}
+
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			// the watcher is stopped
+			return nil
+		}
+
+		return f.fail(err, now)
+	}
+
+	return f.update(src, now)
+}
+
+// update compares the releases of the provided loaded source with the previous
+// ones and returns the events.
+func (f *feed) update(src *source.Remote, now time.Time) []Event {
+	checksum := src.Checksum().String()
+	if checksum == f.checksum {
+		return nil
+	}
+
+	a := appcast.New(src)
+	a.GuessSourceProvider()
+
+	p, errs := a.Unmarshal()
+	if len(errs) > 0 && (p == nil || p.Releases() == nil || p.Releases().Len() == 0) {
+		return f.fail(errs[0], now)
+	}
+
+	var events []Event
+
+	if f.releases != nil {
+		d := diff.Releases(f.releases, p.Releases())
+
+		for _, c := range d.Added {
+			t := NewRelease
+			if c.New.IsPreRelease() {
+				t = NewPreRelease
+			}
+
+			events = append(events, Event{Type: t, Url: f.url, Release: c.New, Time: now})
+		}
+
+		for _, c := range d.Removed {
+			events = append(events, Event{Type: ReleaseRemoved, Url: f.url, Release: c.Old, Time: now})
+		}
+	}
+
+	f.checksum = checksum
+	f.releases = p.Releases()
+	f.broken = false
+
+	return events
+}
+
+// fail marks the feed as broken and returns the FeedBroken event. The event is
+// returned only once until the feed recovers.
+func (f *feed) fail(err error, now time.Time) []Event {
+	f.checksum = ""
+
+	if f.broken {
+		return nil
+	}
+
+	f.broken = true
+
+	return []Event{{Type: FeedBroken, Url: f.url, Err: err, Time: now}}
+}
+
+// dispatch delivers the provided events to all the handlers and channels. The
+// events which haven't been sent to the channels are dropped when the provided
+// context is cancelled.
+func (w *Watcher) dispatch(ctx context.Context, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	handlers := w.handlers
+	notifiers := w.notifiers
+	w.mu.Unlock()
+
+	for _, e := range events {
+		for _, h := range handlers {
+			h(e)
+		}
+	}
+
+	for _, n := range notifiers {
+		n.send(events, ctx.Done())
+	}
+}
+
+// Dropped returns the number of events which have never been sent to the
+// channels added using the Watcher.Notify.
+func (w *Watcher) Dropped() int {
+	w.mu.Lock()
+	notifiers := w.notifiers
+	w.mu.Unlock()
+
+	dropped := 0
+	for _, n := range notifiers {
+		n.Lock()
+		dropped += n.dropped
+		n.Unlock()
+	}
+
+	return dropped
+}
+
+// Releases returns the releases of the last successful poll of the watched
+// feed. Returns nil, if the feed isn't watched or hasn't been polled
+// successfully yet.
+func (w *Watcher) Releases(url string) release.Releaseser {
+	w.mu.Lock()
+	f, ok := w.feeds[url]
+	w.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	return f.releases
+}
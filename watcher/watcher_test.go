@@ -0,0 +1,340 @@
+package watcher
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/victorpopkov/go-appcast/source"
+)
+
+// workingDir returns a current working directory path. If it's not available
+// prints an error to os.Stdout and exits with error status 1.
+func workingDir() string {
+	pwd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	return pwd
+}
+
+// testdata returns a file content as a byte slice from the provided testdata
+// paths. If the file is not found, prints an error to os.Stdout and exits with
+// exit status 1.
+func testdata(paths ...string) []byte {
+	path := filepath.Join(workingDir(), "../provider/sparkle/testdata/unmarshal/", filepath.Join(paths...))
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Println(fmt.Errorf(err.Error()))
+		os.Exit(1)
+	}
+
+	return content
+}
+
+// testFeed represents a mocked remote feed which content can be changed
+// between the polls.
+type testFeed struct {
+	sync.Mutex
+	status  int
+	content []byte
+}
+
+// set sets the testFeed response.
+func (f *testFeed) set(status int, content []byte) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.status = status
+	f.content = content
+}
+
+// responder returns the httpmock.Responder serving the testFeed response.
+func (f *testFeed) responder() httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		f.Lock()
+		defer f.Unlock()
+
+		return httpmock.NewBytesResponse(f.status, f.content), nil
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	assert.Equal(t, "new release", NewRelease.String())
+	assert.Equal(t, "new pre-release", NewPreRelease.String())
+	assert.Equal(t, "release removed", ReleaseRemoved.String())
+	assert.Equal(t, "feed broken", FeedBroken.String())
+}
+
+func TestNew(t *testing.T) {
+	w := New()
+	assert.IsType(t, &Watcher{}, w)
+	assert.NotNil(t, w.feeds)
+}
+
+func TestWatcher_Add(t *testing.T) {
+	// preparations
+	w := New()
+
+	// test (successful)
+	assert.Nil(t, w.Add("https://example.com/appcast.xml"))
+	assert.Equal(t, DefaultInterval, w.feeds["https://example.com/appcast.xml"].interval)
+
+	assert.Nil(t, w.Add("https://example.com/beta.xml", time.Minute))
+	assert.Equal(t, time.Minute, w.feeds["https://example.com/beta.xml"].interval)
+
+	// test (error)
+	err := w.Add("https://example.com/appcast.xml")
+	assert.EqualError(t, err, "feed is already watched: https://example.com/appcast.xml")
+
+	w.running = true
+	err = w.Add("https://example.com/other.xml")
+	assert.EqualError(t, err, "watcher is running")
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	feed := &testFeed{status: 200, content: testdata("default.xml")}
+
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, feed.responder())
+	defer httpmock.DeactivateAndReset()
+
+	var received []Event
+
+	w := New()
+	w.Add(url)
+	w.Subscribe(func(e Event) {
+		received = append(received, e)
+	})
+
+	// test (baseline)
+	events, err := w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 0)
+	assert.Equal(t, 4, w.Releases(url).Len())
+
+	// test (new pre-release and removed release)
+	feed.set(200, testdata("prerelease.xml"))
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, NewPreRelease, events[0].Type)
+	assert.Equal(t, "2.0.0-beta", events[0].Release.Version().String())
+	assert.Equal(t, url, events[0].Url)
+	assert.Equal(t, ReleaseRemoved, events[1].Type)
+	assert.Equal(t, "2.0.0", events[1].Release.Version().String())
+	assert.Equal(t, events, received)
+
+	// test (unchanged checksum)
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 0)
+
+	// test (feed broken)
+	feed.set(404, []byte("Not Found"))
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, FeedBroken, events[0].Type)
+	assert.Nil(t, events[0].Release)
+	assert.EqualError(t, events[0].Err, "releases for the \"Unknown\" provider can't be unmarshaled")
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 0)
+
+	// test (feed recovered)
+	feed.set(200, testdata("default.xml"))
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, NewRelease, events[0].Type)
+	assert.Equal(t, "2.0.0", events[0].Release.Version().String())
+	assert.Equal(t, ReleaseRemoved, events[1].Type)
+	assert.Equal(t, "2.0.0-beta", events[1].Release.Version().String())
+	assert.Len(t, received, 5)
+
+	// test (error)
+	events, err = w.Poll("https://example.com/missing.xml")
+	assert.Nil(t, events)
+	assert.EqualError(t, err, "feed is not watched: https://example.com/missing.xml")
+	assert.Nil(t, w.Releases("https://example.com/missing.xml"))
+}
+
+func TestWatcher_StartStop(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	feed := &testFeed{status: 200, content: testdata("default.xml")}
+
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, feed.responder())
+	defer httpmock.DeactivateAndReset()
+
+	ch := make(chan Event)
+
+	w := New()
+	w.Add(url, 10*time.Millisecond)
+	w.Notify(ch)
+
+	// test
+	w.Start()
+	w.Start()
+
+	for w.Releases(url) == nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	feed.set(200, testdata("prerelease.xml"))
+
+	select {
+	case e := <-ch:
+		assert.Equal(t, NewPreRelease, e.Type)
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+
+	// test (graceful shutdown with the unread event)
+	w.Stop()
+	w.Stop()
+	assert.False(t, w.running)
+}
+
+func TestWatcher_Stop(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	requested := make(chan struct{})
+
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+		close(requested)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	defer httpmock.DeactivateAndReset()
+
+	var received []Event
+
+	w := New()
+	w.Add(url)
+	w.Subscribe(func(e Event) {
+		received = append(received, e)
+	})
+
+	// test (the hanging request is cancelled)
+	w.Start()
+	<-requested
+
+	stopped := make(chan struct{})
+	go func() {
+		w.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		assert.Len(t, received, 0)
+	case <-time.After(time.Second):
+		t.Fatal("watcher not stopped")
+	}
+}
+
+func TestWatcher_Notify(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	feed := &testFeed{status: 200, content: testdata("default.xml")}
+
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, feed.responder())
+	defer httpmock.DeactivateAndReset()
+
+	ch := make(chan Event)
+
+	w := New()
+	w.Add(url)
+	w.Notify(ch)
+
+	// test (polling without Start doesn't block on the unread channel)
+	w.Poll(url)
+	feed.set(200, testdata("prerelease.xml"))
+
+	events, err := w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+
+	feed.set(404, []byte("Not Found"))
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+
+	// test (the queued events are received in order)
+	for _, expected := range []EventType{NewPreRelease, ReleaseRemoved, FeedBroken} {
+		select {
+		case e := <-ch:
+			assert.Equal(t, expected, e.Type)
+		case <-time.After(time.Second):
+			t.Fatal("no event received")
+		}
+	}
+
+	// test (the oldest events are dropped when the queue is full)
+	MaxQueuedEvents = 1
+	defer func() { MaxQueuedEvents = 1000 }()
+
+	feed.set(200, testdata("default.xml"))
+
+	events, err = w.Poll(url)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, 1, w.Dropped())
+
+	select {
+	case e := <-ch:
+		assert.Equal(t, events[1], e)
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func TestWatcher_Dropped(t *testing.T) {
+	// preparations
+	url := "https://example.com/appcast.xml"
+	dispatched := make(chan struct{})
+
+	httpmock.ActivateNonDefault(source.DefaultClient.HTTPClient)
+	httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(404, "Not Found"))
+	defer httpmock.DeactivateAndReset()
+
+	w := New()
+	w.Add(url, time.Hour)
+	w.Subscribe(func(e Event) {
+		close(dispatched)
+	})
+	w.Notify(make(chan Event))
+
+	// test (the unread events are dropped on Stop)
+	w.Start()
+	<-dispatched
+	w.Stop()
+
+	for i := 0; i < 100 && w.Dropped() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, 1, w.Dropped())
+}